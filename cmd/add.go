@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newAddCommand() *cobra.Command {
+	output := ""
+	target := targetGitignore
+
+	command := &cobra.Command{
+		Use:   "add <option...>",
+		Short: "Adds options to an existing .gitignore file",
+		Long: "Adds options to the git-ignore block of an existing .gitignore file, " +
+			"regenerating the block with both the existing and new options",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			outputPath := resolveTargetPath(output, target)
+			existing, block := readManagedBlock(outputPath)
+			requireManagedLockfile(outputPath, block)
+
+			currentOptions := []string{}
+			if block != nil {
+				currentOptions = block.Options
+			}
+
+			options := internal.AddOptions(currentOptions, args)
+			if len(options) == len(currentOptions) {
+				fmt.Println(aurora.Yellow("All of the options are already present"))
+
+				return
+			}
+
+			writeManagedBlock(outputPath, existing, options, targetKeepsLockfile(target))
+
+			fmt.Println(aurora.Sprintf(
+				aurora.Green("Updated %s with %s"),
+				outputPath,
+				strings.Join(options, ", "),
+			))
+		},
+	}
+
+	command.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"File to update (default: .gitignore at the repository root)",
+	)
+	addTargetFlag(command, &target)
+
+	return command
+}
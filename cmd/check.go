@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newCheckCommand() *cobra.Command {
+	output := ""
+
+	command := &cobra.Command{
+		Use:   "check",
+		Short: "Checks that a .gitignore file matches its lockfile",
+		Long: "Regenerates the templates recorded in the lockfile and compares them to the .gitignore file. " +
+			"If the generated rules were edited by hand or don't match the locked revisions " +
+			"the differences are printed and the command fails, which makes it useful in CI.\n\n" +
+			"Only the git-ignore block is checked if the file has one.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := internal.NewClient()
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Error creating client\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			outputPath := resolveOutputPath(output)
+			existing, block := readManagedBlock(outputPath)
+			lockfile := readLockfileOrExit(outputPath)
+			sections := generateLockedOrExit(client, lockfile)
+			generated := internal.IgnoreKindForFile(outputPath).RenderSections(sections)
+
+			expected := generated
+			if block != nil {
+				expected = mergeIntoFile(outputPath, lockfile.Options(), generated)
+			}
+
+			diff := diffIgnoreFile(outputPath, existing, expected)
+			if diff == "" {
+				fmt.Println(aurora.Sprintf(aurora.Green("%s is up to date"), outputPath))
+
+				return
+			}
+
+			fmt.Println(aurora.Sprintf(aurora.Red("%s doesn't match its lockfile"), outputPath))
+			printDiff(diff)
+			os.Exit(1)
+		},
+	}
+
+	command.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"File to check (default: .gitignore at the repository root)",
+	)
+
+	return command
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newDetectCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "detect [directory]",
+		Short: "Detects the options a project needs",
+		Long: "Scans a project for marker files such as go.mod or package.json and lists the options they suggest. " +
+			"Defaults to the root of the current repository.",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			directory := ""
+			if len(args) > 0 {
+				directory = args[0]
+			}
+
+			client, err := internal.NewClient()
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Error creating client\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			detections := detectOrExit(client, detectionRoot(directory))
+			if len(detections) == 0 {
+				fmt.Println(aurora.Yellow("Unable to detect any options"))
+
+				return
+			}
+
+			printDetections(detections)
+		},
+	}
+
+	return command
+}
+
+// detectionRoot returns the directory to scan for marker files,
+// defaulting to the root of the current repository.
+func detectionRoot(directory string) string {
+	if directory != "" {
+		return directory
+	}
+
+	return filepath.Dir(resolveOutputPath(""))
+}
+
+// detectOrExit detects the options for the given directory, exiting
+// if they can't be detected.
+func detectOrExit(client *internal.Client, directory string) []internal.Detection {
+	detections, err := client.Detect(directory)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to detect options\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	return detections
+}
+
+func printDetections(detections []internal.Detection) {
+	for _, detection := range detections {
+		fmt.Printf("%s (%s)\n", aurora.Green(detection.Option), strings.Join(detection.Markers, ", "))
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+// printDiff prints a unified diff with added lines in green and
+// removed lines in red.
+func printDiff(diff string) {
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Println(aurora.Bold(line))
+		case strings.HasPrefix(line, "@@"):
+			fmt.Println(aurora.Cyan(line))
+		case strings.HasPrefix(line, "+"):
+			fmt.Println(aurora.Green(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Println(aurora.Red(line))
+		default:
+			fmt.Println(line)
+		}
+	}
+}
+
+// diffIgnoreFile returns the differences between the current contents
+// of an ignore file and what would be written to it.
+func diffIgnoreFile(filePath string, existing string, contents string) string {
+	name := filepath.Base(filePath)
+
+	return internal.UnifiedDiff("a/"+name, "b/"+name, existing, contents)
+}
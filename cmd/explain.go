@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newExplainCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "explain <path>...",
+		Short: "Explains why a path is or isn't ignored",
+		Long: "Finds the pattern that decides whether each path is ignored the same way git does, looking at the " +
+			".gitignore files in the repository, .git/info/exclude and your global excludes file. " +
+			"Patterns in a git-ignore block are traced back to the template they were generated from.\n\n" +
+			"Paths that don't exist are treated as files unless they end with a slash.",
+		Example: "  git ignore explain bin/server\n" +
+			"  git ignore explain node_modules/",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			rules := loadIgnoreRulesOrExit()
+
+			for _, arg := range args {
+				filePath, isDir := repositoryPathOrExit(rules.Root, arg)
+
+				explanation, err := rules.Explain(filePath, isDir)
+				if err != nil {
+					fmt.Println(
+						aurora.Sprintf(
+							aurora.Red("Unable to explain %s\n%s"),
+							arg,
+							err,
+						),
+					)
+					os.Exit(1)
+				}
+
+				printExplanation(rules.Root, arg, filePath, explanation)
+			}
+		},
+	}
+
+	return command
+}
+
+// repositoryRootOrExit returns the root of the current repository,
+// exiting if it isn't in one.
+func repositoryRootOrExit() string {
+	repoRoot, err := internal.FindRepositoryRoot(".")
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to find the repository root\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	return repoRoot
+}
+
+// loadIgnoreRulesOrExit loads the ignore files for the current
+// repository, exiting if they can't be loaded.
+func loadIgnoreRulesOrExit() *internal.IgnoreRules {
+	rules, err := internal.LoadIgnoreRules(repositoryRootOrExit())
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to load the repository's ignore files\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	return rules
+}
+
+// repositoryPathOrExit returns the given path relative to the root of
+// the repository and whether it's a directory.
+func repositoryPathOrExit(repoRoot string, arg string) (string, bool) {
+	absolutePath, err := filepath.Abs(arg)
+	if err == nil {
+		absolutePath, err = filepath.Rel(repoRoot, absolutePath)
+	}
+
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to resolve %s\n%s"),
+				arg,
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	filePath := filepath.ToSlash(absolutePath)
+	if filePath == "." || filePath == ".." || strings.HasPrefix(filePath, "../") {
+		fmt.Println(aurora.Sprintf(aurora.Red("%s isn't inside of the repository"), arg))
+		os.Exit(1)
+	}
+
+	isDir := strings.HasSuffix(arg, "/")
+
+	info, err := os.Stat(arg)
+	if err == nil {
+		isDir = info.IsDir()
+	}
+
+	return filePath, isDir
+}
+
+func printExplanation(repoRoot string, arg string, filePath string, explanation *internal.Explanation) {
+	if explanation == nil {
+		fmt.Println(aurora.Sprintf("%s isn't matched by any pattern", aurora.Green(arg)))
+
+		return
+	}
+
+	match := explanation.Match
+
+	if explanation.Ignored() {
+		fmt.Println(aurora.Sprintf("%s is ignored", aurora.Yellow(arg)))
+	} else {
+		fmt.Println(aurora.Sprintf("%s is re-included", aurora.Green(arg)))
+	}
+
+	fmt.Printf(
+		"  %s:%d: %s\n",
+		displayPath(repoRoot, match.File.Source),
+		match.Pattern.Line,
+		aurora.Cyan(match.Pattern.Text),
+	)
+
+	if match.Path != filePath {
+		fmt.Printf("  matched its parent directory %s/\n", match.Path)
+	}
+
+	switch {
+	case explanation.Template != nil:
+		fmt.Printf("  generated from %s\n", describeLockedTemplate(*explanation.Template))
+
+	case len(explanation.BlockOptions) > 0 && !explanation.Locked:
+		fmt.Printf(
+			"  generated by the git-ignore block for %s\n",
+			strings.Join(explanation.BlockOptions, ", "),
+		)
+
+	case len(explanation.BlockOptions) > 0:
+		fmt.Printf(
+			"  generated for one of %s but the block doesn't match its lockfile\n",
+			strings.Join(explanation.BlockOptions, ", "),
+		)
+	}
+}
+
+// describeLockedTemplate describes where a locked template came from,
+// e.g. "Go (github: Go at 1a2b3c4)".
+func describeLockedTemplate(template internal.LockedTemplate) string {
+	location := template.Source
+
+	if template.Path != "" {
+		location += ": " + template.Path
+	}
+
+	if template.Revision != "" {
+		location += " at " + shortRevision(template.Revision)
+	}
+
+	return fmt.Sprintf("%s (%s)", aurora.Green(template.Option), location)
+}
+
+// displayPath returns the path relative to the root of the repository
+// if it's inside of it, the same as git check-ignore.
+func displayPath(repoRoot string, filePath string) string {
+	relativePath, err := filepath.Rel(repoRoot, filePath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return filePath
+	}
+
+	return relativePath
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -10,12 +11,45 @@ import (
 	"github.com/durandj/git-ignore/internal"
 )
 
+// stdoutOutput is the value for --output that prints the generated
+// file instead of writing it.
+const stdoutOutput = "-"
+
 func newGenerateCommand() *cobra.Command {
-	return &cobra.Command{
+	output := ""
+	target := targetGitignore
+	force := false
+	merge := false
+	ref := ""
+	locked := false
+	dryRun := false
+	showDiff := false
+	kindName := ""
+
+	command := &cobra.Command{
 		Use:   "generate",
 		Short: "Generates a .gitignore file",
-		Long:  "Generates a .gitignore file based on certain applications or options",
+		Long: "Generates a .gitignore file based on certain applications or options.\n\n" +
+			"Options can be qualified with a source (github:Python) or a template path (community/Golang/Hugo).\n\n" +
+			"By default the file is written to the root of the current git repository. " +
+			"With --merge the generated rules are kept in a marked block inside the existing file " +
+			"so any hand written rules outside of it are preserved.\n\n" +
+			"Use --ref to generate from a specific commit, tag or branch of the template repository " +
+			"or --locked to regenerate exactly what was recorded in the .gitignore.lock file.\n\n" +
+			"Use --dry-run or --diff to see what would change without writing anything.\n\n" +
+			"Use --target=exclude to write to the repository's .git/info/exclude file instead, " +
+			"for rules that shouldn't be committed. The rules are always merged into that file.\n\n" +
+			"Use --kind to generate another kind of ignore file such as a .dockerignore file. " +
+			"Patterns are translated for tools that match them differently to git.",
 		Run: func(cmd *cobra.Command, args []string) {
+			// The exclude file belongs to git so it's never overwritten.
+			merge = merge || target == targetExclude
+
+			kind := resolveKind(kindName, output, target)
+			if output == "" && target == targetGitignore {
+				output = kindPathOrExit(kind)
+			}
+
 			client, err := internal.NewClient()
 			if err != nil {
 				fmt.Println(
@@ -27,19 +61,361 @@ func newGenerateCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
-			contents, err := client.Generate(args)
+			var sections []internal.Section
+
+			switch {
+			case locked:
+				if !targetKeepsLockfile(target) {
+					fmt.Println(aurora.Red("--locked can't be used with --target=exclude since it doesn't have a lockfile"))
+					os.Exit(1)
+				}
+
+				if len(args) > 0 {
+					fmt.Println(aurora.Red("Options can't be given when generating from the lockfile"))
+					os.Exit(1)
+				}
+
+				lockfile := readLockfileOrExit(resolveOutputPath(lockTarget(output)))
+				sections = generateLockedOrExit(client, lockfile)
+				args = lockfile.Options()
+
+			case ref != "":
+				err = client.Pin(ref)
+				if err != nil {
+					fmt.Println(
+						aurora.Sprintf(
+							aurora.Red("Unable to use revision %s\n%s"),
+							ref,
+							err,
+						),
+					)
+					os.Exit(1)
+				}
+
+				sections = generateOrExit(client, args)
+
+			default:
+				sections = generateOrExit(client, args)
+			}
+
+			contents := kind.RenderSections(sections)
+
+			if output == stdoutOutput {
+				if dryRun || showDiff {
+					fmt.Println(aurora.Red("--dry-run and --diff need an output file to compare against"))
+					os.Exit(1)
+				}
+
+				if merge {
+					contents = internal.RenderManagedBlock(args, contents)
+				}
+
+				fmt.Print(contents)
+
+				return
+			}
+
+			outputPath := resolveTargetPath(output, target)
+
+			if merge {
+				contents = mergeIntoFile(outputPath, args, contents)
+			}
+
+			if dryRun || showDiff {
+				previewIgnoreFile(outputPath, contents, force || merge, dryRun)
+
+				return
+			}
+
+			err = internal.WriteIgnoreFile(outputPath, contents, force || merge)
+			if errors.Is(err, internal.ErrIgnoreFileExists) {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("%s already exists, use --force to overwrite it"),
+						outputPath,
+					),
+				)
+				os.Exit(1)
+			}
 
 			if err != nil {
 				fmt.Println(
 					aurora.Sprintf(
-						aurora.Red("Unable generate gitignore file\n%s"),
+						aurora.Red("Unable to write gitignore file\n%s"),
 						err,
 					),
 				)
 				os.Exit(1)
 			}
 
-			fmt.Println(contents)
+			if targetKeepsLockfile(target) {
+				writeLockfile(outputPath, internal.NewLockfile(sections))
+			}
+
+			fmt.Println(aurora.Sprintf(aurora.Green("Wrote %s"), outputPath))
 		},
 	}
+
+	command.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"File to write to, or - for stdout (default: .gitignore at the repository root)",
+	)
+	addTargetFlag(command, &target)
+	command.Flags().BoolVar(&force, "force", false, "Overwrite the output file if it already exists")
+	command.Flags().BoolVar(
+		&merge,
+		"merge",
+		false,
+		"Replace only the git-ignore block in the output file, keeping everything else",
+	)
+	command.Flags().StringVar(&ref, "ref", "", "Generate from a specific commit, tag or branch of the templates")
+	command.Flags().BoolVar(&locked, "locked", false, "Regenerate the templates recorded in the lockfile")
+	command.MarkFlagsMutuallyExclusive("ref", "locked")
+	command.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without writing anything")
+	command.Flags().BoolVar(&showDiff, "diff", false, "Print the changes to the output file without writing anything")
+	command.Flags().StringVar(
+		&kindName,
+		"kind",
+		"",
+		"Kind of ignore file to generate, e.g. dockerignore or npmignore (default: based on the output file name)",
+	)
+
+	return command
+}
+
+// resolveOutputPath returns the file to write to, defaulting to the
+// .gitignore file at the root of the current repository.
+func resolveOutputPath(output string) string {
+	if output != "" {
+		return output
+	}
+
+	outputPath, err := internal.DefaultIgnoreFilePath(".")
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to find the repository root\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	return outputPath
+}
+
+// previewIgnoreFile prints the changes that writing the given contents
+// would make to an ignore file. With summarize it also says whether
+// the file would be written at all.
+func previewIgnoreFile(filePath string, contents string, overwrite bool, summarize bool) {
+	existing, err := internal.ReadIgnoreFile(filePath)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to read existing gitignore file\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	diff := diffIgnoreFile(filePath, existing, contents)
+	printDiff(diff)
+
+	if !summarize {
+		return
+	}
+
+	_, err = os.Stat(filePath)
+	exists := err == nil
+
+	switch {
+	case exists && !overwrite:
+		fmt.Println(aurora.Sprintf(aurora.Yellow("%s already exists, use --force to overwrite it"), filePath))
+	case diff == "":
+		fmt.Println(aurora.Sprintf(aurora.Green("%s is unchanged"), filePath))
+	default:
+		fmt.Println(aurora.Sprintf(aurora.Green("Would write %s"), filePath))
+	}
+}
+
+// resolveKind returns the kind of ignore file to generate. Without a
+// kind it is based on the name of the output file.
+func resolveKind(kindName string, output string, target string) internal.IgnoreKind {
+	if kindName == "" {
+		return internal.IgnoreKindForFile(output)
+	}
+
+	kind, err := internal.LookupIgnoreKind(kindName)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Invalid --kind\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	if target == targetExclude && kind.FileName != internal.IgnoreFileName {
+		fmt.Println(aurora.Sprintf(aurora.Red("The exclude file can't be used for %s files"), kind.Name))
+		os.Exit(1)
+	}
+
+	return kind
+}
+
+// kindPathOrExit returns the default path of the given kind of ignore
+// file, exiting if it can't be found.
+func kindPathOrExit(kind internal.IgnoreKind) string {
+	outputPath, err := kind.DefaultPath(".")
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to find the repository root\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	return outputPath
+}
+
+// mergeIntoFile merges the managed block into the current contents of
+// the given file.
+func mergeIntoFile(filePath string, options []string, generated string) string {
+	existing, err := internal.ReadIgnoreFile(filePath)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to read existing gitignore file\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	merged, err := internal.MergeManagedBlock(existing, options, generated)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to merge into %s\n%s"),
+				filePath,
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	return merged
+}
+
+// generateOrExit generates the sections of an ignore file for the
+// given options, exiting if they can't be generated.
+func generateOrExit(client *internal.Client, options []string) []internal.Section {
+	sections, err := client.GenerateSections(options)
+
+	var invalidOptionErr *internal.InvalidOptionError
+	if errors.As(err, &invalidOptionErr) {
+		printInvalidOption(invalidOptionErr)
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable generate gitignore file\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	return sections
+}
+
+// lockTarget returns the output whose lockfile should be used. When
+// printing to stdout the lockfile for the default output is used.
+func lockTarget(output string) string {
+	if output == stdoutOutput {
+		return ""
+	}
+
+	return output
+}
+
+// readLockfileOrExit reads the lockfile for the given ignore file,
+// exiting if there isn't one.
+func readLockfileOrExit(ignoreFilePath string) *internal.Lockfile {
+	lockfilePath := internal.LockfilePath(ignoreFilePath)
+
+	lockfile, err := internal.ReadLockfile(lockfilePath)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to read lockfile\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	if lockfile == nil {
+		fmt.Println(aurora.Sprintf(aurora.Red("%s doesn't exist"), lockfilePath))
+		os.Exit(1)
+	}
+
+	return lockfile
+}
+
+// generateLockedOrExit regenerates the sections recorded in the
+// lockfile, exiting if they can't be generated.
+func generateLockedOrExit(client *internal.Client, lockfile *internal.Lockfile) []internal.Section {
+	sections, err := client.GenerateLocked(lockfile)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to generate gitignore file from the lockfile\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	return sections
+}
+
+// writeLockfile saves the lockfile alongside the ignore file it was
+// generated for.
+func writeLockfile(ignoreFilePath string, lockfile *internal.Lockfile) {
+	err := lockfile.Write(internal.LockfilePath(ignoreFilePath))
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to write lockfile\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+}
+
+func printInvalidOption(err *internal.InvalidOptionError) {
+	fmt.Println(aurora.Sprintf(aurora.Red("Unknown option \"%s\""), err.Option))
+
+	if len(err.Suggestions) == 0 {
+		fmt.Println("Run `git ignore list` to see all the available options")
+
+		return
+	}
+
+	fmt.Println("Did you mean:")
+
+	for _, suggestion := range err.Suggestions {
+		fmt.Printf("  %s\n", aurora.Green(suggestion))
+	}
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newGlobalCommand() *cobra.Command {
+	remove := false
+
+	command := &cobra.Command{
+		Use:   "global [option...]",
+		Short: "Manages your global excludes file",
+		Long: "Adds options to the git-ignore block of your global excludes file, i.e. the file set by " +
+			"core.excludesFile in your git config or $XDG_CONFIG_HOME/git/ignore if it isn't set. " +
+			"This is the place for rules that are personal to you such as the files your OS or editor creates.\n\n" +
+			"Without any options the options already in the file are listed.",
+		Example: "  git ignore global macOS JetBrains Vim VisualStudioCode\n" +
+			"  git ignore global --remove Vim",
+		Run: func(cmd *cobra.Command, args []string) {
+			excludesPath, err := internal.GlobalExcludesFilePath()
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Unable to find the global excludes file\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			existing, block := readManagedBlock(excludesPath)
+
+			current := []string{}
+			if block != nil {
+				current = block.Options
+			}
+
+			if len(args) == 0 {
+				if len(current) == 0 {
+					fmt.Printf("%s doesn't have any options yet\n", excludesPath)
+
+					return
+				}
+
+				fmt.Printf("%s has %s\n", excludesPath, strings.Join(current, ", "))
+
+				return
+			}
+
+			options := internal.AddOptions(current, args)
+
+			if remove {
+				var missing []string
+
+				options, missing = internal.RemoveOptions(current, args)
+				if len(missing) > 0 {
+					fmt.Println(aurora.Sprintf(
+						aurora.Yellow("Skipping options that aren't present: %s"),
+						strings.Join(missing, ", "),
+					))
+				}
+
+				if len(options) == len(current) {
+					return
+				}
+			}
+
+			err = os.MkdirAll(filepath.Dir(excludesPath), 0o750)
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Unable to create the directory for %s\n%s"),
+						excludesPath,
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			// The global excludes file isn't part of any repository so
+			// there's nothing to commit a lockfile alongside.
+			writeManagedBlock(excludesPath, existing, options, false)
+
+			if len(options) == 0 {
+				fmt.Println(aurora.Sprintf(aurora.Green("Removed the git-ignore block from %s"), excludesPath))
+
+				return
+			}
+
+			fmt.Println(aurora.Sprintf(
+				aurora.Green("Updated %s with %s"),
+				excludesPath,
+				strings.Join(options, ", "),
+			))
+		},
+	}
+
+	command.Flags().BoolVar(&remove, "remove", false, "Remove the given options instead of adding them")
+
+	return command
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newInitCommand() *cobra.Command {
+	output := ""
+	yes := false
+
+	command := &cobra.Command{
+		Use:   "init [option...]",
+		Short: "Generates a .gitignore file for the detected project types",
+		Long: "Detects the options a project needs from its marker files and generates a .gitignore file with them " +
+			"after asking for confirmation. Any options given are added to the detected ones.\n\n" +
+			"The rules are kept in a git-ignore block so an existing .gitignore file is never overwritten.",
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := internal.NewClient()
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Error creating client\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			outputPath := resolveOutputPath(output)
+			detections := detectOrExit(client, filepath.Dir(outputPath))
+
+			options := []string{}
+			for _, detection := range detections {
+				options = append(options, detection.Option)
+			}
+
+			options = internal.AddOptions(options, args)
+			if len(options) == 0 {
+				fmt.Println(aurora.Yellow("Unable to detect any options, try `git ignore generate` instead"))
+				os.Exit(1)
+			}
+
+			existing, block := readManagedBlock(outputPath)
+			requireManagedLockfile(outputPath, block)
+
+			if block != nil {
+				options = internal.AddOptions(block.Options, options)
+			}
+
+			if len(detections) > 0 {
+				fmt.Println("Detected:")
+				printDetections(detections)
+			}
+
+			if !yes && !confirm(fmt.Sprintf("Generate %s with %s?", outputPath, strings.Join(options, ", "))) {
+				return
+			}
+
+			writeManagedBlock(outputPath, existing, options, true)
+
+			fmt.Println(aurora.Sprintf(
+				aurora.Green("Wrote %s with %s"),
+				outputPath,
+				strings.Join(options, ", "),
+			))
+		},
+	}
+
+	command.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"File to write to (default: .gitignore at the repository root)",
+	)
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "Generate the file without asking")
+
+	return command
+}
@@ -12,7 +12,9 @@ import (
 )
 
 func newListCommand() *cobra.Command {
-	return &cobra.Command{
+	showSources := false
+
+	command := &cobra.Command{
 		Use:   "list",
 		Short: "Gets a list of all possible gitignore options",
 		Long:  "Retrieves a list of all the options that can be specified for creating a .gitignore file",
@@ -28,7 +30,7 @@ func newListCommand() *cobra.Command {
 				os.Exit(1)
 			}
 
-			options, err := client.List()
+			options, err := client.Options()
 			if err != nil {
 				fmt.Println(
 					aurora.Sprintf(
@@ -40,7 +42,25 @@ func newListCommand() *cobra.Command {
 			}
 
 			fmt.Println(aurora.Bold("Options:"))
-			fmt.Println(strings.Join(options, ", "))
+
+			if showSources {
+				for _, option := range options {
+					fmt.Printf("%s %s\n", option.Name, aurora.Faint("("+strings.Join(option.Sources, ", ")+")"))
+				}
+
+				return
+			}
+
+			names := make([]string, 0, len(options))
+			for _, option := range options {
+				names = append(names, option.Name)
+			}
+
+			fmt.Println(strings.Join(names, ", "))
 		},
 	}
+
+	command.Flags().BoolVar(&showSources, "sources", false, "Show which sources provide each option")
+
+	return command
 }
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+// readManagedBlock reads the given ignore file and the managed block
+// in it. The block is nil if the file doesn't have one.
+func readManagedBlock(filePath string) (string, *internal.ManagedBlock) {
+	existing, err := internal.ReadIgnoreFile(filePath)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to read existing gitignore file\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	block, err := internal.FindManagedBlock(existing)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to read the git-ignore block in %s\n%s"),
+				filePath,
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	return existing, block
+}
+
+// requireManagedLockfile exits if the ignore file doesn't have a
+// managed block but does have a lockfile. The file was generated
+// without --merge so its lockfile describes the whole file and adding
+// a block next to it would leave some of the generated rules out of
+// the lockfile.
+func requireManagedLockfile(filePath string, block *internal.ManagedBlock) {
+	if block != nil {
+		return
+	}
+
+	lockfile, err := internal.ReadLockfile(internal.LockfilePath(filePath))
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to read lockfile\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	if lockfile == nil {
+		return
+	}
+
+	fmt.Println(aurora.Sprintf(
+		aurora.Red("%s was generated without a git-ignore block so its options can't be changed"),
+		filePath,
+	))
+	fmt.Printf(
+		"Regenerate it with a block first, e.g. `rm %s && git ignore generate --merge %s`\n",
+		filePath,
+		strings.Join(lockfile.Options(), " "),
+	)
+	os.Exit(1)
+}
+
+// writeManagedBlock regenerates the managed block in the ignore file
+// with the given options. The block is removed if there aren't any
+// options left. The lockfile is only kept up to date if lock is set,
+// files that are never committed don't need one.
+func writeManagedBlock(filePath string, existing string, options []string, lock bool) {
+	var (
+		contents string
+		lockfile *internal.Lockfile
+		err      error
+	)
+
+	if len(options) == 0 {
+		contents, err = internal.RemoveManagedBlock(existing)
+	} else {
+		client, clientErr := internal.NewClient()
+		if clientErr != nil {
+			fmt.Println(
+				aurora.Sprintf(
+					aurora.Red("Error creating client\n%s"),
+					clientErr,
+				),
+			)
+			os.Exit(1)
+		}
+
+		sections := generateOrExit(client, options)
+		lockfile = internal.NewLockfile(sections)
+		contents, err = internal.MergeManagedBlock(
+			existing,
+			options,
+			internal.IgnoreKindForFile(filePath).RenderSections(sections),
+		)
+	}
+
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to update %s\n%s"),
+				filePath,
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	err = internal.WriteIgnoreFile(filePath, contents, true)
+	if err != nil {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to write gitignore file\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+
+	if !lock {
+		return
+	}
+
+	if lockfile != nil {
+		writeLockfile(filePath, lockfile)
+
+		return
+	}
+
+	err = os.Remove(internal.LockfilePath(filePath))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Println(
+			aurora.Sprintf(
+				aurora.Red("Unable to remove lockfile\n%s"),
+				err,
+			),
+		)
+		os.Exit(1)
+	}
+}
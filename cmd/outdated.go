@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+// shortRevisionLength is how much of a commit hash is shown.
+const shortRevisionLength = 7
+
+func newOutdatedCommand() *cobra.Command {
+	output := ""
+
+	command := &cobra.Command{
+		Use:   "outdated",
+		Short: "Lists the templates that changed upstream",
+		Long: "Lists the templates recorded in the lockfile that have changed upstream since they were generated " +
+			"along with the commits that changed them. Run `git ignore update` first to fetch the latest templates " +
+			"and `git ignore upgrade` to apply the changes.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := internal.NewClient()
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Error creating client\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			lockfile := readLockfileOrExit(resolveOutputPath(output))
+
+			outdated, err := client.Outdated(lockfile)
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Unable to check for upstream changes\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			if len(outdated) == 0 {
+				fmt.Println(aurora.Green("All templates are up to date"))
+
+				return
+			}
+
+			for _, template := range outdated {
+				printOutdatedTemplate(template)
+			}
+		},
+	}
+
+	command.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"File to check (default: .gitignore at the repository root)",
+	)
+
+	return command
+}
+
+func printOutdatedTemplate(template internal.OutdatedTemplate) {
+	locked := template.Template
+
+	if len(template.Changes) == 0 {
+		fmt.Println(aurora.Sprintf("%s (%s) has changed", aurora.Yellow(locked.Option), locked.Source))
+
+		return
+	}
+
+	changeCount := "1 change"
+	if len(template.Changes) > 1 {
+		changeCount = fmt.Sprintf("%d changes", len(template.Changes))
+	}
+
+	fmt.Println(aurora.Sprintf(
+		"%s (%s) has %s since %s",
+		aurora.Yellow(locked.Option),
+		locked.Source,
+		changeCount,
+		shortRevision(locked.Revision),
+	))
+
+	for _, change := range template.Changes {
+		fmt.Printf(
+			"  %s %s %s\n",
+			aurora.Cyan(shortRevision(change.Revision)),
+			change.When.Format("2006-01-02"),
+			change.Summary,
+		)
+	}
+}
+
+func shortRevision(revision string) string {
+	return revision[:min(len(revision), shortRevisionLength)]
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newPreviewCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "preview <option>...",
+		Short: "Lists the existing files the given options would ignore",
+		Long: "Generates the given options and lists the files and directories in the repository that would " +
+			"become ignored if they were added to the .gitignore file, so you can make sure they don't hide any " +
+			"source files. Nothing is written.\n\n" +
+			"Tracked files are listed separately since git keeps tracking them even once they match an ignore " +
+			"rule, which usually means they should be removed with `git rm --cached` or the rule is wrong.",
+		Example: "  git ignore preview Go Node",
+		Args:    cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := internal.NewClient()
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Error creating client\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			sections := generateOrExit(client, args)
+
+			previewed, err := internal.PreviewIgnored(repositoryRootOrExit(), sections)
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Unable to preview the ignored files\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			if len(previewed) == 0 {
+				fmt.Println(aurora.Green("None of the existing files would be ignored"))
+
+				return
+			}
+
+			untracked := []internal.PreviewedPath{}
+			tracked := []internal.PreviewedPath{}
+
+			for _, path := range previewed {
+				if path.Tracked {
+					tracked = append(tracked, path)
+				} else {
+					untracked = append(untracked, path)
+				}
+			}
+
+			if len(untracked) > 0 {
+				fmt.Println(aurora.Bold("Would be ignored:"))
+				printPreviewedPaths(untracked)
+			}
+
+			if len(tracked) > 0 {
+				fmt.Println(aurora.Bold("Tracked files that would be ignored but stay tracked:"))
+				printPreviewedPaths(tracked)
+			}
+		},
+	}
+
+	return command
+}
+
+func printPreviewedPaths(paths []internal.PreviewedPath) {
+	for _, path := range paths {
+		fmt.Printf(
+			"  %s (%s: %s)\n",
+			aurora.Yellow(path.Path),
+			path.Option,
+			aurora.Cyan(path.Pattern.Text),
+		)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newRemoveCommand() *cobra.Command {
+	output := ""
+	target := targetGitignore
+
+	command := &cobra.Command{
+		Use:   "remove <option...>",
+		Short: "Removes options from an existing .gitignore file",
+		Long: "Removes options from the git-ignore block of an existing .gitignore file, " +
+			"regenerating the block with the remaining options",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			outputPath := resolveTargetPath(output, target)
+			existing, block := readManagedBlock(outputPath)
+
+			if block == nil {
+				fmt.Println(aurora.Sprintf(aurora.Red("%s doesn't have a git-ignore block"), outputPath))
+				os.Exit(1)
+			}
+
+			options, missing := internal.RemoveOptions(block.Options, args)
+			if len(missing) > 0 {
+				fmt.Println(aurora.Sprintf(
+					aurora.Yellow("Skipping options that aren't present: %s"),
+					strings.Join(missing, ", "),
+				))
+			}
+
+			if len(options) == len(block.Options) {
+				return
+			}
+
+			writeManagedBlock(outputPath, existing, options, targetKeepsLockfile(target))
+
+			if len(options) == 0 {
+				fmt.Println(aurora.Sprintf(aurora.Green("Removed the git-ignore block from %s"), outputPath))
+
+				return
+			}
+
+			fmt.Println(aurora.Sprintf(
+				aurora.Green("Updated %s with %s"),
+				outputPath,
+				strings.Join(options, ", "),
+			))
+		},
+	}
+
+	command.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"File to update (default: .gitignore at the repository root)",
+	)
+	addTargetFlag(command, &target)
+
+	return command
+}
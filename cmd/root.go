@@ -12,13 +12,23 @@ func Execute() {
 	rootCmd := &cobra.Command{
 		Use:   "git-ignore",
 		Short: ".gitignore generator",
-		Long:  "Generates contents for a .gitignore file using gitignore.io",
+		Long:  "Generates contents for a .gitignore file using github/gitignore or gitignore.io",
 	}
 
 	rootCmd.AddCommand(
+		newAddCommand(),
+		newCheckCommand(),
+		newDetectCommand(),
+		newExplainCommand(),
 		newGenerateCommand(),
+		newGlobalCommand(),
+		newInitCommand(),
 		newListCommand(),
+		newOutdatedCommand(),
+		newPreviewCommand(),
+		newRemoveCommand(),
 		newUpdateCommand(),
+		newUpgradeCommand(),
 		newVersionCommand(),
 	)
 
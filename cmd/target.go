@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+const (
+	// targetGitignore is the .gitignore file at the root of the
+	// repository.
+	targetGitignore = "gitignore"
+
+	// targetExclude is the repository's info/exclude file. Its rules
+	// only apply to the local clone and are never committed.
+	targetExclude = "exclude"
+)
+
+func addTargetFlag(command *cobra.Command, target *string) {
+	command.Flags().StringVar(
+		target,
+		"target",
+		targetGitignore,
+		"File to use when --output isn't given, either gitignore or exclude for .git/info/exclude",
+	)
+	command.MarkFlagsMutuallyExclusive("output", "target")
+}
+
+// targetKeepsLockfile reports whether a lockfile is written next to
+// the target's file. The exclude file is never committed and lives in
+// git's own directory where "<file>.lock" means the file is locked, so
+// it doesn't get one.
+func targetKeepsLockfile(target string) bool {
+	return target != targetExclude
+}
+
+// resolveTargetPath returns the file to use for the given output and
+// target.
+func resolveTargetPath(output string, target string) string {
+	switch target {
+	case targetGitignore:
+		return resolveOutputPath(output)
+
+	case targetExclude:
+		excludePath, err := internal.ExcludeFilePath(".")
+		if err == nil {
+			err = os.MkdirAll(filepath.Dir(excludePath), 0o750)
+		}
+
+		if err != nil {
+			fmt.Println(
+				aurora.Sprintf(
+					aurora.Red("Unable to find the repository's exclude file\n%s"),
+					err,
+				),
+			)
+			os.Exit(1)
+		}
+
+		return excludePath
+
+	default:
+		fmt.Println(aurora.Sprintf(
+			aurora.Red("Unknown target \"%s\", expected %s or %s"),
+			target,
+			targetGitignore,
+			targetExclude,
+		))
+		os.Exit(1)
+
+		return ""
+	}
+}
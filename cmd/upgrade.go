@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/logrusorgru/aurora/v4"
+	"github.com/spf13/cobra"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newUpgradeCommand() *cobra.Command {
+	output := ""
+	yes := false
+
+	command := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Regenerates a .gitignore file with the latest templates",
+		Long: "Regenerates the templates recorded in the lockfile using the latest version of each template. " +
+			"The changes are shown before anything is written. " +
+			"Run `git ignore update` first to fetch the latest templates.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := internal.NewClient()
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Error creating client\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			outputPath := resolveOutputPath(output)
+			existing, block := readManagedBlock(outputPath)
+			lockfile := readLockfileOrExit(outputPath)
+			options := lockfile.Options()
+			sections := generateOrExit(client, options)
+
+			expected := internal.IgnoreKindForFile(outputPath).RenderSections(sections)
+			if block != nil {
+				expected = mergeIntoFile(outputPath, options, expected)
+			}
+
+			diff := diffIgnoreFile(outputPath, existing, expected)
+			if diff == "" {
+				// The templates may not have changed even though the
+				// revisions they came from did.
+				writeLockfile(outputPath, internal.NewLockfile(sections))
+				fmt.Println(aurora.Sprintf(aurora.Green("%s is already up to date"), outputPath))
+
+				return
+			}
+
+			printDiff(diff)
+
+			if !yes && !confirm(fmt.Sprintf("Apply these changes to %s?", outputPath)) {
+				return
+			}
+
+			err = internal.WriteIgnoreFile(outputPath, expected, true)
+			if err != nil {
+				fmt.Println(
+					aurora.Sprintf(
+						aurora.Red("Unable to write gitignore file\n%s"),
+						err,
+					),
+				)
+				os.Exit(1)
+			}
+
+			writeLockfile(outputPath, internal.NewLockfile(sections))
+
+			fmt.Println(aurora.Sprintf(aurora.Green("Upgraded %s"), outputPath))
+		},
+	}
+
+	command.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"File to upgrade (default: .gitignore at the repository root)",
+	)
+	command.Flags().BoolVarP(&yes, "yes", "y", false, "Apply the changes without asking")
+
+	return command
+}
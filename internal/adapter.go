@@ -1,8 +1,16 @@
 package internal
 
+import (
+	"time"
+)
+
 // Adapter is any adapter that the git-ignore client can use to
 // retrieve content for generating a gitignore file.
 type Adapter interface {
+	// SourceName returns the name of the source this adapter pulls
+	// templates from.
+	SourceName() string
+
 	// List returns the list of options that can be used to generate a
 	// gitignore file.
 	List() ([]string, error)
@@ -13,3 +21,68 @@ type Adapter interface {
 	// Update updates this plugin's local data.
 	Update() error
 }
+
+// PathAdapter is any adapter that organizes its templates in nested
+// directories. Options for these adapters can be qualified with the
+// path of the template, e.g. "community/Golang/Hugo".
+type PathAdapter interface {
+	Adapter
+
+	// ListPaths returns the path of every template without the file
+	// extension.
+	ListPaths() ([]string, error)
+}
+
+// Describer is any adapter that can report where a template came from
+// so that it can be recorded in a lockfile.
+type Describer interface {
+	Adapter
+
+	// Describe returns where the template for the given option came
+	// from.
+	Describe(option string) (TemplateInfo, error)
+}
+
+// TemplateInfo describes where a template came from.
+type TemplateInfo struct {
+	// Path is the template's path within its source.
+	Path string
+
+	// URL is the location of the template's source.
+	URL string
+
+	// Revision is the version of the source the template was read
+	// from, e.g. a commit hash. It is empty for unversioned sources.
+	Revision string
+}
+
+// Pinner is any adapter that can generate templates as they were at a
+// specific revision of its source.
+type Pinner interface {
+	Adapter
+
+	// Pin makes the adapter read templates as they were at the given
+	// revision. An empty revision unpins the adapter.
+	Pin(revision string) error
+}
+
+// Historian is any adapter that can report how a template changed
+// between revisions of its source.
+type Historian interface {
+	Adapter
+
+	// Changes returns the changes to the template at the given path
+	// since the given revision, newest first.
+	Changes(templatePath string, since string) ([]TemplateChange, error)
+}
+
+// TemplateChange is a single upstream change to a template.
+type TemplateChange struct {
+	// Revision is the commit that made the change.
+	Revision string
+
+	// Summary is the first line of the change's description.
+	Summary string
+
+	When time.Time
+}
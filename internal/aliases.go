@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"strings"
+)
+
+// DefaultAliases returns the built in alternative names for options
+// keyed by the lower case alias.
+func DefaultAliases() map[string]string {
+	return map[string]string{
+		"c++":      "C++",
+		"cpp":      "C++",
+		"golang":   "Go",
+		"idea":     "JetBrains",
+		"intellij": "JetBrains",
+		"js":       "Node",
+		"mac":      "macOS",
+		"node":     "Node",
+		"nodejs":   "Node",
+		"osx":      "macOS",
+		"py":       "Python",
+		"rb":       "Ruby",
+		"rs":       "Rust",
+		"tf":       "Terraform",
+		"ts":       "Node",
+		"vscode":   "VisualStudioCode",
+	}
+}
+
+// resolveAlias returns the option the given alias refers to. Aliases
+// are matched without regard to case and aliases configured on the
+// client take precedence over the defaults.
+func (client *Client) resolveAlias(alias string) (string, bool) {
+	for configuredAlias, option := range client.Aliases {
+		if strings.EqualFold(configuredAlias, alias) {
+			return option, true
+		}
+	}
+
+	option, ok := DefaultAliases()[strings.ToLower(alias)]
+
+	return option, ok
+}
+
+// matchOption finds the given option in a list of options. Exact
+// matches are preferred but if there aren't any the option is matched
+// without regard to case. It returns the option as it appears in the
+// list.
+func matchOption(options []string, option string) (string, bool) {
+	for _, candidate := range options {
+		if candidate == option {
+			return candidate, true
+		}
+	}
+
+	for _, candidate := range options {
+		if strings.EqualFold(candidate, option) {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"errors"
 	"fmt"
+	"path"
 	"slices"
 	"strings"
 )
@@ -12,81 +13,554 @@ import (
 // and turn them into a gitignore file.
 type Client struct {
 	Adapters []Adapter
+
+	// Aliases are alternative names for options in addition to the
+	// default aliases.
+	Aliases map[string]string
+
+	// DetectionRules are the rules used to detect the options a
+	// project needs. The built in rules are used if it is nil.
+	DetectionRules []DetectionRule
+
+	// pinnedRevision is the revision the adapters were pinned to, if
+	// any. Only adapters that can be pinned are used while it is set.
+	pinnedRevision string
 }
 
-// NewClient creates a new client for generating gitignore files.
+// NewClient creates a new client for generating gitignore files
+// using the sources from the user's configuration.
 func NewClient() (*Client, error) {
-	gitAdapter, err := NewGitAdapter()
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load configuration: %w", err)
+	}
+
+	return NewClientFromConfig(config)
+}
+
+// NewClientFromConfig creates a new client for generating gitignore
+// files using the sources from the given configuration.
+func NewClientFromConfig(config *Config) (*Client, error) {
+	adapters := make([]Adapter, 0, len(config.Sources))
+
+	for _, source := range config.Sources {
+		adapter, err := source.NewAdapter()
+		if err != nil {
+			return nil, fmt.Errorf("unable to create adapter for source \"%s\": %w", source.Name, err)
+		}
+
+		adapters = append(adapters, adapter)
+	}
+
+	detectionRules, err := LoadDetectionRules(config.DetectionRules)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create Git adapter: %w", err)
+		return nil, err
 	}
 
 	return &Client{
-		Adapters: []Adapter{
-			gitAdapter,
-		},
+		Adapters:       adapters,
+		Aliases:        config.Aliases,
+		DetectionRules: detectionRules,
+		pinnedRevision: "",
 	}, nil
 }
 
-// List returns a list of valid options for generating a gitignore
-// file. Each of these options maps to a service or application that
-// generates file that should be excluded from a git repository.
-func (client *Client) List() ([]string, error) {
+// Option is a template that can be used to generate a gitignore
+// file along with the sources that provide it.
+type Option struct {
+	Name string
+
+	// Sources are the names of the adapters that provide this option
+	// in priority order.
+	Sources []string
+}
+
+// Options returns every option provided by any of the client's
+// adapters. Options provided by more than one adapter are only
+// returned once.
+func (client *Client) Options() ([]Option, error) {
 	adapterErrors := []error{}
+	options := []Option{}
+	optionIndexes := map[string]int{}
+	anyAdapterSucceeded := false
 
 	for _, adapter := range client.Adapters {
-		options, err := adapter.List()
-
+		adapterOptions, err := adapter.List()
 		if err != nil {
 			adapterErrors = append(adapterErrors, err)
 
 			continue
 		}
 
-		slices.SortFunc(options, func(a, b string) int {
-			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
-		})
+		anyAdapterSucceeded = true
+
+		for _, name := range adapterOptions {
+			key := strings.ToLower(name)
+
+			index, ok := optionIndexes[key]
+			if !ok {
+				optionIndexes[key] = len(options)
+				options = append(options, Option{Name: name, Sources: []string{}})
+				index = len(options) - 1
+			}
 
-		return options, nil
+			if !slices.Contains(options[index].Sources, adapter.SourceName()) {
+				options[index].Sources = append(options[index].Sources, adapter.SourceName())
+			}
+		}
+	}
+
+	if !anyAdapterSucceeded {
+		return nil, fmt.Errorf("unable to retrieve option list:\n%s", adapterErrors)
+	}
+
+	slices.SortFunc(options, func(a, b Option) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return options, nil
+}
+
+// List returns a list of valid options for generating a gitignore
+// file. Each of these options maps to a service or application that
+// generates file that should be excluded from a git repository.
+func (client *Client) List() ([]string, error) {
+	options, err := client.Options()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(options))
+	for _, option := range options {
+		names = append(names, option.Name)
 	}
 
-	return nil, fmt.Errorf("unable to retrieve option list:\n%s", adapterErrors)
+	return names, nil
 }
 
 // Generate generates a .gitignore file that excludes files based on
-// the given options.
+// the given options. Each option is generated by the highest priority
+// adapter that provides it and the results are combined in the order
+// the options were given.
 func (client *Client) Generate(options []string) (string, error) {
+	sections, err := client.GenerateSections(options)
+	if err != nil {
+		return "", err
+	}
+
+	return RenderSections(sections), nil
+}
+
+// Section is the generated content for a single option.
+type Section struct {
+	// Option is the option as it was requested.
+	Option string
+
+	// Source is the name of the adapter that generated the section.
+	Source string
+
+	// Template describes where the section's template came from.
+	Template TemplateInfo
+
+	Content string
+}
+
+// RenderSections combines generated sections into a single gitignore
+// file.
+func RenderSections(sections []Section) string {
+	var builder strings.Builder
+	for _, section := range sections {
+		builder.WriteString(section.Content)
+
+		if !strings.HasSuffix(section.Content, "\n") {
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String()
+}
+
+// GenerateSections generates the content for each of the given options
+// separately. Each option is generated by the highest priority adapter
+// that provides it and the sections are returned in the order the
+// options were given. Adapters are only asked for their options once
+// the adapters before them don't provide one so slow remote sources
+// aren't used unless they're needed.
+func (client *Client) GenerateSections(options []string) ([]Section, error) {
 	if len(options) == 0 {
-		return "", errors.New("must give at least one option")
+		return nil, errors.New("must give at least one option")
 	}
 
-	adapterErrors := []error{}
+	lister := client.newOptionLister()
+	sections := make([]Section, 0, len(options))
 
-	for _, adapter := range client.Adapters {
-		validOptions, err := adapter.List()
+	for _, option := range options {
+		source, name := ParseOption(option)
+
+		if source != "" && !slices.ContainsFunc(client.Adapters, func(adapter Adapter) bool {
+			return adapter.SourceName() == source
+		}) {
+			return nil, fmt.Errorf("unknown source \"%s\" in option \"%s\"", source, option)
+		}
+
+		section, err := client.generateSection(lister, option, source, name)
 		if err != nil {
-			adapterErrors = append(adapterErrors, err)
+			return nil, err
+		}
+
+		if section != nil {
+			sections = append(sections, *section)
 
 			continue
 		}
 
-		for _, option := range options {
-			if !slices.Contains(validOptions, option) {
-				return "", fmt.Errorf("invalid option \"%s\"", option)
-			}
+		if !lister.anyListed() {
+			return nil, fmt.Errorf("unable to generate gitignore:\n%s", lister.errors)
+		}
+
+		return nil, &InvalidOptionError{
+			Option:      option,
+			Suggestions: lister.suggestionsFor(source, name),
+		}
+	}
+
+	return sections, nil
+}
+
+// generateSection generates the section for a single option using the
+// first adapter that provides it, falling back to the adapters after it
+// if generating fails. It returns nil if none of the adapters provide
+// the option.
+func (client *Client) generateSection(
+	lister *optionLister,
+	option string,
+	source string,
+	name string,
+) (*Section, error) {
+	names := []string{name}
+	if alias, ok := client.resolveAlias(name); ok {
+		names = append(names, alias)
+	}
+
+	found := false
+	unpinned := []int{}
+
+	for index, adapter := range client.Adapters {
+		if source != "" && adapter.SourceName() != source {
+			continue
+		}
+
+		// Falling back to a source that can't be pinned would quietly
+		// generate the latest version of the template instead.
+		if _, ok := adapter.(Pinner); !ok && client.pinnedRevision != "" {
+			unpinned = append(unpinned, index)
+
+			continue
+		}
+
+		matchedName, ok := lister.match(index, names)
+		if !ok {
+			continue
+		}
+
+		found = true
+
+		content, err := adapter.Generate([]string{matchedName})
+
+		var ambiguousErr *AmbiguousOptionError
+		if errors.As(err, &ambiguousErr) {
+			return nil, err
 		}
 
-		content, err := adapter.Generate(options)
 		if err != nil {
-			adapterErrors = append(adapterErrors, err)
+			lister.errors = append(lister.errors, err)
 
 			continue
 		}
 
-		return content, nil
+		template, err := describeTemplate(adapter, matchedName)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Section{
+			Option:   option,
+			Source:   adapter.SourceName(),
+			Template: template,
+			Content:  content,
+		}, nil
+	}
+
+	if found {
+		return nil, fmt.Errorf("unable to generate gitignore for %s:\n%s", option, lister.errors)
+	}
+
+	for _, index := range unpinned {
+		if _, ok := lister.match(index, names); ok {
+			return nil, fmt.Errorf(
+				"%s is only provided by source \"%s\" which can't generate revision %s",
+				option,
+				client.Adapters[index].SourceName(),
+				client.pinnedRevision,
+			)
+		}
+	}
+
+	return nil, nil
+}
+
+// describeTemplate returns where the adapter's template for the given
+// option came from if the adapter is able to tell.
+func describeTemplate(adapter Adapter, option string) (TemplateInfo, error) {
+	describer, ok := adapter.(Describer)
+	if !ok {
+		return TemplateInfo{Path: "", URL: "", Revision: ""}, nil
+	}
+
+	template, err := describer.Describe(option)
+	if err != nil {
+		return template, fmt.Errorf("unable to describe template for %s: %w", option, err)
+	}
+
+	return template, nil
+}
+
+// optionLister lists the options of a client's adapters as they're
+// needed and remembers them so each adapter is only asked once.
+type optionLister struct {
+	adapters []Adapter
+
+	// options are the options of each adapter, nil if the adapter
+	// hasn't been listed yet or failed to list them.
+	options [][]string
+	paths   [][]string
+	listed  []bool
+
+	errors []error
+}
+
+func (client *Client) newOptionLister() *optionLister {
+	return &optionLister{
+		adapters: client.Adapters,
+		options:  make([][]string, len(client.Adapters)),
+		paths:    make([][]string, len(client.Adapters)),
+		listed:   make([]bool, len(client.Adapters)),
+		errors:   []error{},
+	}
+}
+
+// list returns the options of the adapter at the given index, or nil
+// if they can't be listed.
+func (lister *optionLister) list(index int) []string {
+	if lister.listed[index] {
+		return lister.options[index]
+	}
+
+	lister.listed[index] = true
+
+	options, err := lister.adapters[index].List()
+	if err != nil {
+		lister.errors = append(lister.errors, err)
+
+		return nil
+	}
+
+	lister.options[index] = options
+
+	return options
+}
+
+// anyListed reports whether any of the adapters that have been asked
+// were able to list their options.
+func (lister *optionLister) anyListed() bool {
+	return slices.ContainsFunc(lister.options, func(options []string) bool {
+		return options != nil
+	})
+}
+
+// match finds the first of the given names that the adapter at the
+// given index provides, returning the name the adapter uses for it.
+// Names with a slash are matched against the paths of the adapter's
+// templates if it has them.
+func (lister *optionLister) match(index int, names []string) (string, bool) {
+	options := lister.list(index)
+	if options == nil {
+		return "", false
+	}
+
+	for _, name := range names {
+		if !strings.Contains(name, "/") {
+			if matchedName, ok := matchOption(options, name); ok {
+				return matchedName, true
+			}
+
+			continue
+		}
+
+		templatePaths := lister.listPaths(index)
+		if matchedPath, ok := matchOption(templatePaths, name); ok {
+			return matchedPath, true
+		}
+	}
+
+	return "", false
+}
+
+// listPaths returns the template paths of the adapter at the given
+// index, or nil if it doesn't have any.
+func (lister *optionLister) listPaths(index int) []string {
+	if lister.paths[index] != nil {
+		return lister.paths[index]
+	}
+
+	pathAdapter, ok := lister.adapters[index].(PathAdapter)
+	if !ok {
+		return nil
+	}
+
+	templatePaths, err := pathAdapter.ListPaths()
+	if err != nil {
+		lister.errors = append(lister.errors, err)
+
+		return nil
+	}
+
+	lister.paths[index] = templatePaths
+
+	return templatePaths
+}
+
+// suggestionsFor returns the options close to the given template
+// name from every adapter that could have provided it.
+func (lister *optionLister) suggestionsFor(source string, name string) []string {
+	options := []string{}
+
+	for index, adapter := range lister.adapters {
+		if source != "" && adapter.SourceName() != source {
+			continue
+		}
+
+		options = append(options, lister.list(index)...)
+	}
+
+	return SuggestOptions(options, path.Base(name))
+}
+
+// ParseOption splits an option into the name of the source it should
+// be generated from and the name of the template. Options can be
+// qualified with a source name, e.g. "github:Python", and/or the
+// path of the template, e.g. "community/Golang/Hugo". The source is
+// empty when the option isn't qualified with one.
+func ParseOption(option string) (string, string) {
+	source, name, found := strings.Cut(option, ":")
+	if !found {
+		return "", option
+	}
+
+	return source, name
+}
+
+// Pin makes every adapter that supports it generate templates as
+// they were at the given revision, e.g. a commit hash, tag or branch.
+// Adapters that don't support it aren't used until the client is
+// unpinned with an empty revision.
+func (client *Client) Pin(revision string) error {
+	pinnedAny := false
+
+	for _, adapter := range client.Adapters {
+		pinner, ok := adapter.(Pinner)
+		if !ok {
+			continue
+		}
+
+		err := pinner.Pin(revision)
+		if err != nil {
+			return fmt.Errorf("unable to pin %s to %s: %w", adapter.SourceName(), revision, err)
+		}
+
+		pinnedAny = true
+	}
+
+	if !pinnedAny {
+		return errors.New("none of the sources support generating from a specific revision")
+	}
+
+	client.pinnedRevision = revision
+
+	return nil
+}
+
+// GenerateLocked regenerates the sections recorded in a lockfile. Each
+// section is generated by the same source, from the same template and
+// at the same revision that was recorded.
+func (client *Client) GenerateLocked(lockfile *Lockfile) ([]Section, error) {
+	pinnedAdapters := []Pinner{}
+
+	defer func() {
+		for _, pinner := range pinnedAdapters {
+			_ = pinner.Pin("")
+		}
+	}()
+
+	sections := make([]Section, 0, len(lockfile.Templates))
+
+	for _, template := range lockfile.Templates {
+		adapter, name, err := client.lockedAdapter(template)
+		if err != nil {
+			return nil, err
+		}
+
+		if template.Revision != "" {
+			pinner, ok := adapter.(Pinner)
+			if !ok {
+				return nil, fmt.Errorf("source \"%s\" can't generate a specific revision", template.Source)
+			}
+
+			err = pinner.Pin(template.Revision)
+			if err != nil {
+				return nil, fmt.Errorf("unable to pin %s to %s: %w", template.Source, template.Revision, err)
+			}
+
+			pinnedAdapters = append(pinnedAdapters, pinner)
+		}
+
+		content, err := adapter.Generate([]string{name})
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate locked option %s: %w", template.Option, err)
+		}
+
+		info, err := describeTemplate(adapter, name)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, Section{
+			Option:   template.Option,
+			Source:   template.Source,
+			Template: info,
+			Content:  content,
+		})
+	}
+
+	return sections, nil
+}
+
+// lockedAdapter returns the adapter a locked template was generated
+// by along with the name of the template within it.
+func (client *Client) lockedAdapter(template LockedTemplate) (Adapter, string, error) {
+	index := slices.IndexFunc(client.Adapters, func(adapter Adapter) bool {
+		return adapter.SourceName() == template.Source
+	})
+	if index < 0 {
+		return nil, "", fmt.Errorf("unknown source \"%s\" for locked option \"%s\"", template.Source, template.Option)
+	}
+
+	name := template.Path
+	if name == "" {
+		_, name = ParseOption(template.Option)
 	}
 
-	return "", fmt.Errorf("unable to generate gitignore:\n%s", adapterErrors)
+	return client.Adapters[index], name, nil
 }
 
 // Update updates all local cache adapters.
@@ -12,8 +12,8 @@ import (
 func TestClientListWithErrorInAdapterShouldFallbackToNextAdapter(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -36,8 +36,8 @@ func TestClientListWithErrorInAdapterShouldFallbackToNextAdapter(t *testing.T) {
 func TestClientListWithErrorInAllAdaptersShouldReturnAnError(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -58,8 +58,8 @@ func TestClientListWithErrorInAllAdaptersShouldReturnAnError(t *testing.T) {
 func TestClientListShouldRetrieveAListOfOptions(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -70,6 +70,7 @@ func TestClientListShouldRetrieveAListOfOptions(t *testing.T) {
 
 	expectedOptions := []string{"c", "c++"}
 	primaryAdapter.addListReturn(expectedOptions, nil)
+	secondaryAdapter.addListReturn(nil, nil)
 
 	options, err := client.List()
 
@@ -77,11 +78,59 @@ func TestClientListShouldRetrieveAListOfOptions(t *testing.T) {
 	require.Equal(t, expectedOptions, options)
 }
 
+func TestClientListShouldMergeOptionsFromAllAdapters(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			&secondaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Python", "C"}, nil)
+	secondaryAdapter.addListReturn([]string{"python", "Bazel-Internal"}, nil)
+
+	options, err := client.List()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Bazel-Internal", "C", "Python"}, options)
+}
+
+func TestClientOptionsShouldRecordWhichAdaptersProvideEachOption(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			&secondaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Python", "C"}, nil)
+	secondaryAdapter.addListReturn([]string{"python", "Bazel-Internal"}, nil)
+
+	options, err := client.Options()
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.Option{
+		{Name: "Bazel-Internal", Sources: []string{"secondary"}},
+		{Name: "C", Sources: []string{"primary"}},
+		{Name: "Python", Sources: []string{"primary", "secondary"}},
+	}, options)
+}
+
 func TestClientGenerateWithNoOptionsShouldReturnAnError(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -98,8 +147,8 @@ func TestClientGenerateWithNoOptionsShouldReturnAnError(t *testing.T) {
 func TestClientGenerateWithAnInvalidOptionShouldReturnAnError(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -109,6 +158,7 @@ func TestClientGenerateWithAnInvalidOptionShouldReturnAnError(t *testing.T) {
 	}
 
 	primaryAdapter.addListReturn([]string{"c"}, nil)
+	secondaryAdapter.addListReturn(nil, nil)
 
 	_, err := client.Generate([]string{"doesnotexist"})
 
@@ -118,8 +168,8 @@ func TestClientGenerateWithAnInvalidOptionShouldReturnAnError(t *testing.T) {
 func TestClientGenerateWithASingleOptionShouldGenerateAGitignoreFile(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -129,6 +179,7 @@ func TestClientGenerateWithASingleOptionShouldGenerateAGitignoreFile(t *testing.
 	}
 
 	primaryAdapter.addListReturn([]string{"c", "c++"}, nil)
+	secondaryAdapter.addListReturn(nil, nil)
 	primaryAdapter.addGenerateReturn("### C ###", nil)
 
 	file, err := client.Generate([]string{"c"})
@@ -140,8 +191,8 @@ func TestClientGenerateWithASingleOptionShouldGenerateAGitignoreFile(t *testing.
 func TestClientGenerateWithMultipleOptionsShouldGenerateAGitignoreFile(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -151,7 +202,9 @@ func TestClientGenerateWithMultipleOptionsShouldGenerateAGitignoreFile(t *testin
 	}
 
 	primaryAdapter.addListReturn([]string{"c", "c++"}, nil)
-	primaryAdapter.addGenerateReturn("### C ###\n\n### C++ ###", nil)
+	secondaryAdapter.addListReturn(nil, nil)
+	primaryAdapter.addGenerateReturn("### C ###\n", nil)
+	primaryAdapter.addGenerateReturn("### C++ ###\n", nil)
 
 	file, err := client.Generate([]string{"c", "c++"})
 
@@ -163,8 +216,8 @@ func TestClientGenerateWithMultipleOptionsShouldGenerateAGitignoreFile(t *testin
 func TestClientGenerateWithAnErrorInAnAdapterShouldFallbackToNextAdapter(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -186,8 +239,8 @@ func TestClientGenerateWithAnErrorInAnAdapterShouldFallbackToNextAdapter(t *test
 func TestClientGenerateWithAnErrorInAllAdaptersShouldReturnAnError(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -207,8 +260,8 @@ func TestClientGenerateWithAnErrorInAllAdaptersShouldReturnAnError(t *testing.T)
 func TestClientUpdateShouldUpdateAllAdapters(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -234,8 +287,8 @@ func TestClientUpdateShouldUpdateAllAdapters(t *testing.T) {
 func TestClientUpdateWithAnErrorInOneOrMoreAdaptersShouldReturnAnError(t *testing.T) {
 	t.Parallel()
 
-	primaryAdapter := newFakeAdapter()
-	secondaryAdapter := newFakeAdapter()
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
 
 	client := internal.Client{
 		Adapters: []internal.Adapter{
@@ -251,3 +304,372 @@ func TestClientUpdateWithAnErrorInOneOrMoreAdaptersShouldReturnAnError(t *testin
 
 	require.Error(t, err)
 }
+
+func TestClientGenerateShouldRouteEachOptionToTheAdapterThatProvidesIt(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			&secondaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Python"}, nil)
+	secondaryAdapter.addListReturn([]string{"OurInternalTemplate"}, nil)
+	primaryAdapter.addGenerateReturn("### Python ###\n", nil)
+	secondaryAdapter.addGenerateReturn("### OurInternalTemplate ###\n", nil)
+
+	file, err := client.Generate([]string{"OurInternalTemplate", "Python"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### OurInternalTemplate ###\n### Python ###\n", file)
+	require.Equal(t, []string{"Python"}, primaryAdapter.getGenerateCalls()[0].options)
+	require.Equal(t, []string{"OurInternalTemplate"}, secondaryAdapter.getGenerateCalls()[0].options)
+}
+
+func TestClientGenerateShouldPreferTheHighestPriorityAdapter(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			&secondaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Python"}, nil)
+	secondaryAdapter.addListReturn([]string{"Python"}, nil)
+	primaryAdapter.addGenerateReturn("### Primary Python ###\n", nil)
+
+	file, err := client.Generate([]string{"Python"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### Primary Python ###\n", file)
+	require.Empty(t, secondaryAdapter.getGenerateCalls())
+}
+
+func TestClientGenerateShouldNotListLowerPriorityAdaptersThatArentNeeded(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			&secondaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Go", "Python"}, nil)
+	primaryAdapter.addGenerateReturn("### Go ###\n", nil)
+	primaryAdapter.addGenerateReturn("### Python ###\n", nil)
+
+	_, err := client.Generate([]string{"Go", "Python"})
+
+	require.NoError(t, err)
+	require.Len(t, primaryAdapter.getListCalls(), 1)
+	require.Empty(t, secondaryAdapter.getListCalls())
+}
+
+func TestClientGenerateWithAnErrorGeneratingShouldFallbackToTheNextAdapterWithTheOption(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			&secondaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Python"}, nil)
+	secondaryAdapter.addListReturn([]string{"Python"}, nil)
+	primaryAdapter.addGenerateReturn("", errors.New("Test error"))
+	secondaryAdapter.addGenerateReturn("### Secondary Python ###\n", nil)
+
+	file, err := client.Generate([]string{"Python"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### Secondary Python ###\n", file)
+}
+
+func TestClientGenerateWithASourceQualifiedOptionShouldOnlyUseThatSource(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			&secondaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Python"}, nil)
+	secondaryAdapter.addListReturn([]string{"Python"}, nil)
+	secondaryAdapter.addGenerateReturn("### Secondary Python ###\n", nil)
+
+	file, err := client.Generate([]string{"secondary:Python"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### Secondary Python ###\n", file)
+	require.Empty(t, primaryAdapter.getGenerateCalls())
+	require.Equal(t, []string{"Python"}, secondaryAdapter.getGenerateCalls()[0].options)
+}
+
+func TestClientGenerateWithAnUnknownSourceShouldReturnAnError(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Python"}, nil)
+
+	_, err := client.Generate([]string{"doesnotexist:Python"})
+
+	require.ErrorContains(t, err, "unknown source")
+}
+
+func TestClientGenerateWithAPathQualifiedOptionShouldUseTheTemplateAtThatPath(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"Hugo.gitignore":                  "/public/\n",
+		"community/Golang/Hugo.gitignore": "/resources/_gen/\n",
+	})
+
+	primaryAdapter := newFakeAdapter("primary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			internal.NewDirectoryAdapter("internal", testDir),
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Hugo"}, nil)
+
+	file, err := client.Generate([]string{"internal:community/Golang/Hugo"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### Hugo ###\n/resources/_gen/\n\n", file)
+}
+
+func TestParseOptionShouldSplitTheSourceFromTheTemplate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		option         string
+		expectedSource string
+		expectedName   string
+	}{
+		{option: "Python", expectedSource: "", expectedName: "Python"},
+		{option: "github:Python", expectedSource: "github", expectedName: "Python"},
+		{option: "community/Golang/Hugo", expectedSource: "", expectedName: "community/Golang/Hugo"},
+		{option: "github:community/Golang/Hugo", expectedSource: "github", expectedName: "community/Golang/Hugo"},
+	}
+
+	for _, testCase := range testCases {
+		source, name := internal.ParseOption(testCase.option)
+
+		require.Equal(t, testCase.expectedSource, source, testCase.option)
+		require.Equal(t, testCase.expectedName, name, testCase.option)
+	}
+}
+
+func TestClientGenerateShouldMatchOptionsWithoutRegardToCase(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Python"}, nil)
+	primaryAdapter.addGenerateReturn("### Python ###\n", nil)
+
+	_, err := client.Generate([]string{"python"})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Python"}, primaryAdapter.getGenerateCalls()[0].options)
+}
+
+func TestClientGenerateShouldResolveDefaultAliases(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Go", "VisualStudioCode"}, nil)
+	primaryAdapter.addGenerateReturn("### Go ###\n", nil)
+	primaryAdapter.addGenerateReturn("### VisualStudioCode ###\n", nil)
+
+	_, err := client.Generate([]string{"golang", "VSCode"})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go"}, primaryAdapter.getGenerateCalls()[0].options)
+	require.Equal(t, []string{"VisualStudioCode"}, primaryAdapter.getGenerateCalls()[1].options)
+}
+
+func TestClientGenerateShouldResolveConfiguredAliases(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+		},
+		Aliases: map[string]string{
+			"K8s": "Kubernetes",
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Kubernetes"}, nil)
+	primaryAdapter.addGenerateReturn("### Kubernetes ###\n", nil)
+
+	_, err := client.Generate([]string{"k8s"})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Kubernetes"}, primaryAdapter.getGenerateCalls()[0].options)
+}
+
+func TestClientGenerateShouldPreferTemplatesOverAliases(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"Go", "Golang"}, nil)
+	primaryAdapter.addGenerateReturn("### Golang ###\n", nil)
+
+	_, err := client.Generate([]string{"golang"})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Golang"}, primaryAdapter.getGenerateCalls()[0].options)
+}
+
+func TestClientGenerateWithAnInvalidOptionShouldSuggestCloseMatches(t *testing.T) {
+	t.Parallel()
+
+	primaryAdapter := newFakeAdapter("primary")
+	secondaryAdapter := newFakeAdapter("secondary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			&secondaryAdapter,
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"C", "Python"}, nil)
+	secondaryAdapter.addListReturn([]string{"python", "Ruby"}, nil)
+
+	_, err := client.Generate([]string{"Pyhton"})
+
+	var invalidOptionErr *internal.InvalidOptionError
+
+	require.True(t, errors.As(err, &invalidOptionErr))
+	require.Equal(t, "Pyhton", invalidOptionErr.Option)
+	require.Equal(t, []string{"Python"}, invalidOptionErr.Suggestions)
+}
+
+func TestClientGenerateSectionsShouldDescribeWhereEachSectionCameFrom(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"Bazel-Internal.gitignore": "bazel-*\n",
+	})
+
+	primaryAdapter := newFakeAdapter("primary")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			&primaryAdapter,
+			internal.NewDirectoryAdapter("internal", testDir),
+		},
+	}
+
+	primaryAdapter.addListReturn([]string{"C"}, nil)
+	primaryAdapter.addGenerateReturn("### C ###\n*.o\n", nil)
+
+	sections, err := client.GenerateSections([]string{"C", "bazel-internal"})
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.Section{
+		{
+			Option:   "C",
+			Source:   "primary",
+			Template: internal.TemplateInfo{Path: "", URL: "", Revision: ""},
+			Content:  "### C ###\n*.o\n",
+		},
+		{
+			Option:   "bazel-internal",
+			Source:   "internal",
+			Template: internal.TemplateInfo{Path: "Bazel-Internal", URL: testDir, Revision: ""},
+			Content:  "### Bazel-Internal ###\nbazel-*\n\n",
+		},
+	}, sections)
+}
+
+func TestClientPinShouldNotFallBackToSourcesThatCantBePinned(t *testing.T) {
+	t.Parallel()
+
+	gitAdapter := newLocalGitAdapter(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+	httpAdapter := newFakeAdapter("gitignoreio")
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{
+			gitAdapter,
+			&httpAdapter,
+		},
+	}
+
+	httpAdapter.addListReturn([]string{"C", "Python"}, nil)
+
+	err := client.Pin("master")
+	require.NoError(t, err)
+
+	_, err = client.Generate([]string{"Python"})
+
+	require.ErrorContains(t, err, "Python is only provided by source \"gitignoreio\"")
+	require.Empty(t, httpAdapter.getGenerateCalls())
+
+	content, err := client.Generate([]string{"C"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### C ###\n*.o\n\n", content)
+}
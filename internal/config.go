@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigEnvVar is the environment variable that can be used to point
+// git-ignore at a specific configuration file.
+const ConfigEnvVar string = "GIT_IGNORE_CONFIG"
+
+const (
+	// SourceTypeGit is a source backed by a git repository of
+	// templates.
+	SourceTypeGit string = "git"
+
+	// SourceTypeHTTP is a source backed by a gitignore.io compatible
+	// API.
+	SourceTypeHTTP string = "http"
+
+	// SourceTypeDirectory is a source backed by a local directory of
+	// templates.
+	SourceTypeDirectory string = "directory"
+)
+
+// Config is the user configuration for git-ignore.
+type Config struct {
+	// Sources are the template sources to use in priority order.
+	Sources []SourceConfig `yaml:"sources"`
+
+	// Aliases are alternative names for options, e.g. "k8s" for
+	// "Kubernetes".
+	Aliases map[string]string `yaml:"aliases"`
+
+	// DetectionRules are paths to files with extra rules for detecting
+	// the options a project needs, e.g. ones shared across an
+	// organization. The user's own rules file is loaded after these.
+	DetectionRules []string `yaml:"detection_rules"`
+}
+
+// SourceConfig describes a single source of gitignore templates.
+type SourceConfig struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	URL      string `yaml:"url"`
+	Path     string `yaml:"path"`
+	SyncPath string `yaml:"sync_path"`
+}
+
+// DefaultConfig returns the configuration used when the user hasn't
+// configured anything.
+func DefaultConfig() *Config {
+	return &Config{
+		Sources: []SourceConfig{
+			{Name: DefaultGitSourceName, Type: SourceTypeGit, URL: "", Path: "", SyncPath: ""},
+			{Name: DefaultHTTPSourceName, Type: SourceTypeHTTP, URL: "", Path: "", SyncPath: ""},
+		},
+		Aliases:        map[string]string{},
+		DetectionRules: []string{},
+	}
+}
+
+// ConfigPath returns the location of the user's configuration file.
+func ConfigPath() (string, error) {
+	if configPath := os.Getenv(ConfigEnvVar); configPath != "" {
+		return configPath, nil
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		currentUser, err := user.Current()
+		if err != nil {
+			return "", fmt.Errorf("unable to get user home directory: %w", err)
+		}
+
+		configHome = path.Join(currentUser.HomeDir, ".config")
+	}
+
+	return path.Join(configHome, "git-ignore", "config.yaml"), nil
+}
+
+// LoadConfig loads the user's configuration file falling back to the
+// default configuration if there isn't one.
+func LoadConfig() (*Config, error) {
+	configPath, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadConfigFile(configPath)
+}
+
+// LoadConfigFile loads the configuration at the given path falling
+// back to the default configuration if the file doesn't exist.
+func LoadConfigFile(configPath string) (*Config, error) {
+	config, err := readConfigFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// The user's detection rules live next to their configuration and
+	// take precedence over any others.
+	rulesPath := path.Join(path.Dir(configPath), DetectionRulesFileName)
+
+	_, err = os.Stat(rulesPath)
+	if err == nil {
+		config.DetectionRules = append(config.DetectionRules, rulesPath)
+	}
+
+	return config, nil
+}
+
+func readConfigFile(configPath string) (*Config, error) {
+	contents, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultConfig(), nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file %s: %w", configPath, err)
+	}
+
+	//nolint:exhaustruct // Populated by the decoder
+	config := &Config{}
+
+	err = yaml.Unmarshal(contents, config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse config file %s: %w", configPath, err)
+	}
+
+	if len(config.Sources) == 0 {
+		config.Sources = DefaultConfig().Sources
+	}
+
+	err = config.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", configPath, err)
+	}
+
+	return config, nil
+}
+
+func (config *Config) validate() error {
+	names := map[string]bool{}
+
+	for index, source := range config.Sources {
+		if source.Name == "" {
+			return fmt.Errorf("source %d is missing a name", index)
+		}
+
+		if names[source.Name] {
+			return fmt.Errorf("duplicate source name \"%s\"", source.Name)
+		}
+
+		names[source.Name] = true
+
+		switch source.Type {
+		case SourceTypeGit, SourceTypeHTTP:
+		case SourceTypeDirectory:
+			if source.Path == "" {
+				return fmt.Errorf("directory source \"%s\" is missing a path", source.Name)
+			}
+		default:
+			return fmt.Errorf("source \"%s\" has unknown type \"%s\"", source.Name, source.Type)
+		}
+	}
+
+	return nil
+}
+
+// NewAdapter creates the adapter described by this source.
+func (source SourceConfig) NewAdapter() (Adapter, error) {
+	switch source.Type {
+	case SourceTypeGit:
+		adapter, err := NewGitAdapter()
+		if err != nil {
+			return nil, err
+		}
+
+		adapter.Name = source.Name
+
+		if source.URL != "" {
+			adapter.RepoURL = source.URL
+			adapter.RepoDirectory = path.Join(path.Dir(adapter.RepoDirectory), source.Name)
+		}
+
+		if source.Path != "" {
+			adapter.RepoDirectory = source.Path
+		}
+
+		return adapter, nil
+
+	case SourceTypeHTTP:
+		adapter := NewHTTPAdapter()
+		adapter.Name = source.Name
+
+		if source.URL != "" {
+			adapter.BaseURL = source.URL
+		}
+
+		return adapter, nil
+
+	case SourceTypeDirectory:
+		adapter := NewDirectoryAdapter(source.Name, source.Path)
+		adapter.SyncDirectory = source.SyncPath
+
+		return adapter, nil
+	}
+
+	return nil, fmt.Errorf("unknown source type \"%s\"", source.Type)
+}
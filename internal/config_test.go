@@ -0,0 +1,114 @@
+package internal_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestLoadConfigFileShouldReturnTheDefaultConfigWhenTheFileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	config, err := internal.LoadConfigFile(filepath.Join(t.TempDir(), "config.yaml"))
+
+	require.NoError(t, err)
+	require.Equal(t, internal.DefaultConfig(), config)
+}
+
+func TestLoadConfigFileShouldReadSources(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"config.yaml": `sources:
+  - name: internal
+    type: directory
+    path: /srv/gitignore-templates
+  - name: github
+    type: git
+aliases:
+  k8s: Kubernetes
+`,
+	})
+
+	config, err := internal.LoadConfigFile(filepath.Join(testDir, "config.yaml"))
+
+	require.NoError(t, err)
+	require.Len(t, config.Sources, 2)
+	require.Equal(t, "internal", config.Sources[0].Name)
+	require.Equal(t, internal.SourceTypeDirectory, config.Sources[0].Type)
+	require.Equal(t, "/srv/gitignore-templates", config.Sources[0].Path)
+	require.Equal(t, internal.SourceTypeGit, config.Sources[1].Type)
+	require.Equal(t, map[string]string{"k8s": "Kubernetes"}, config.Aliases)
+}
+
+func TestLoadConfigFileShouldReturnAnErrorForAnUnknownSourceType(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"config.yaml": "sources:\n  - name: internal\n    type: ftp\n",
+	})
+
+	_, err := internal.LoadConfigFile(filepath.Join(testDir, "config.yaml"))
+
+	require.Error(t, err)
+}
+
+func TestLoadConfigFileShouldReturnAnErrorForADirectorySourceWithoutAPath(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"config.yaml": "sources:\n  - name: internal\n    type: directory\n",
+	})
+
+	_, err := internal.LoadConfigFile(filepath.Join(testDir, "config.yaml"))
+
+	require.Error(t, err)
+}
+
+func TestNewClientFromConfigShouldCreateAnAdapterPerSource(t *testing.T) {
+	t.Parallel()
+
+	config := &internal.Config{
+		Sources: []internal.SourceConfig{
+			{Name: "internal", Type: internal.SourceTypeDirectory, URL: "", Path: "/srv/templates", SyncPath: ""},
+			{Name: "github", Type: internal.SourceTypeGit, URL: "", Path: "", SyncPath: ""},
+			{Name: "gitignoreio", Type: internal.SourceTypeHTTP, URL: "http://localhost", Path: "", SyncPath: ""},
+		},
+	}
+
+	client, err := internal.NewClientFromConfig(config)
+
+	require.NoError(t, err)
+	require.Len(t, client.Adapters, 3)
+	require.IsType(t, &internal.DirectoryAdapter{}, client.Adapters[0])
+	require.IsType(t, &internal.GitAdapter{}, client.Adapters[1])
+	require.IsType(t, &internal.HTTPAdapter{}, client.Adapters[2])
+	require.Equal(t, "http://localhost", client.Adapters[2].(*internal.HTTPAdapter).BaseURL)
+}
+
+func TestLoadConfigFileShouldLoadTheUserDetectionRulesLast(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"config.yaml": `detection_rules:
+  - /etc/git-ignore/detection.yaml
+`,
+		"detection.yaml": "rules: []\n",
+	})
+
+	config, err := internal.LoadConfigFile(filepath.Join(testDir, "config.yaml"))
+
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]string{"/etc/git-ignore/detection.yaml", filepath.Join(testDir, "detection.yaml")},
+		config.DetectionRules,
+	)
+}
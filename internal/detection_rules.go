@@ -0,0 +1,122 @@
+package internal
+
+import (
+	// Needed to embed the default detection rules.
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DetectionRulesFileName is the name of the user's detection rules
+// file which lives next to their configuration file.
+const DetectionRulesFileName string = "detection.yaml"
+
+//go:embed detection_rules.yaml
+var defaultDetectionRules []byte
+
+// DetectionRule maps a marker file to the option it suggests, e.g.
+// go.mod to Go.
+type DetectionRule struct {
+	// Glob is matched against the name of each file. It matches
+	// directories instead if it ends with a slash, e.g. ".idea/".
+	Glob string `yaml:"glob"`
+
+	// Content is an optional regular expression that the contents of
+	// a matching file must also match.
+	Content string `yaml:"content"`
+
+	// Option is the option to suggest when the rule matches.
+	Option string `yaml:"option"`
+
+	content *regexp.Regexp
+}
+
+// detectionRulesFile is the format of a detection rules file.
+type detectionRulesFile struct {
+	// Replace drops all of the rules loaded before this file,
+	// including the built in ones.
+	Replace bool `yaml:"replace"`
+
+	Rules []DetectionRule `yaml:"rules"`
+}
+
+// DefaultDetectionRules returns the built in rules for detecting the
+// tools used in a project.
+func DefaultDetectionRules() ([]DetectionRule, error) {
+	rulesFile, err := parseDetectionRules(defaultDetectionRules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid built in detection rules: %w", err)
+	}
+
+	return rulesFile.Rules, nil
+}
+
+// LoadDetectionRules returns the built in detection rules combined
+// with the rules in the given files. Rules from later files are added
+// to the ones before them unless the file replaces them.
+func LoadDetectionRules(rulesPaths []string) ([]DetectionRule, error) {
+	rules, err := DefaultDetectionRules()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rulesPath := range rulesPaths {
+		contents, err := os.ReadFile(rulesPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read detection rules %s: %w", rulesPath, err)
+		}
+
+		rulesFile, err := parseDetectionRules(contents)
+		if err != nil {
+			return nil, fmt.Errorf("invalid detection rules %s: %w", rulesPath, err)
+		}
+
+		if rulesFile.Replace {
+			rules = []DetectionRule{}
+		}
+
+		rules = append(rules, rulesFile.Rules...)
+	}
+
+	return rules, nil
+}
+
+func parseDetectionRules(contents []byte) (*detectionRulesFile, error) {
+	//nolint:exhaustruct // Populated by the decoder
+	rulesFile := &detectionRulesFile{}
+
+	err := yaml.Unmarshal(contents, rulesFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse detection rules: %w", err)
+	}
+
+	for index := range rulesFile.Rules {
+		rule := &rulesFile.Rules[index]
+
+		if rule.Glob == "" {
+			return nil, fmt.Errorf("rule %d is missing a glob", index)
+		}
+
+		if _, err := path.Match(rule.Glob, ""); errors.Is(err, path.ErrBadPattern) {
+			return nil, fmt.Errorf("rule %d has an invalid glob \"%s\"", index, rule.Glob)
+		}
+
+		if rule.Option == "" {
+			return nil, fmt.Errorf("rule %d is missing an option", index)
+		}
+
+		if rule.Content != "" {
+			rule.content, err = regexp.Compile(rule.Content)
+			if err != nil {
+				return nil, fmt.Errorf("rule %d has an invalid content pattern: %w", index, err)
+			}
+		}
+	}
+
+	return rulesFile, nil
+}
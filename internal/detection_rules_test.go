@@ -0,0 +1,140 @@
+package internal_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestDefaultDetectionRulesShouldBeValid(t *testing.T) {
+	t.Parallel()
+
+	rules, err := internal.DefaultDetectionRules()
+
+	require.NoError(t, err)
+	require.NotEmpty(t, rules)
+}
+
+func TestLoadDetectionRulesShouldAddRulesToTheDefaults(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"rules.yaml": `rules:
+  - glob: BUILD.acme
+    option: Acme
+`,
+	})
+
+	rules, err := internal.LoadDetectionRules([]string{filepath.Join(testDir, "rules.yaml")})
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	writeProjectFiles(t, root, "go.mod", "service/BUILD.acme")
+
+	detections, err := internal.DetectOptions(root, rules)
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.Detection{
+		{Option: "Acme", Markers: []string{"service/BUILD.acme"}},
+		{Option: "Go", Markers: []string{"go.mod"}},
+	}, detections)
+}
+
+func TestLoadDetectionRulesShouldReplaceEarlierRules(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"org.yaml": `rules:
+  - glob: BUILD.acme
+    option: Acme
+`,
+		"user.yaml": `replace: true
+rules:
+  - glob: go.mod
+    option: Golang
+`,
+	})
+
+	rules, err := internal.LoadDetectionRules([]string{
+		filepath.Join(testDir, "org.yaml"),
+		filepath.Join(testDir, "user.yaml"),
+	})
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	writeProjectFiles(t, root, "go.mod", "BUILD.acme")
+
+	detections, err := internal.DetectOptions(root, rules)
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.Detection{
+		{Option: "Golang", Markers: []string{"go.mod"}},
+	}, detections)
+}
+
+func TestDetectOptionsShouldMatchFileContents(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"rules.yaml": `replace: true
+rules:
+  - glob: package.json
+    content: '"next"\s*:'
+    option: Nextjs
+`,
+	})
+
+	rules, err := internal.LoadDetectionRules([]string{filepath.Join(testDir, "rules.yaml")})
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	writeTemplates(t, root, map[string]string{
+		"web/package.json": `{"dependencies": {"next": "14.0.0"}}`,
+		"api/package.json": `{"dependencies": {"express": "4.0.0"}}`,
+	})
+
+	detections, err := internal.DetectOptions(root, rules)
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.Detection{
+		{Option: "Nextjs", Markers: []string{"web/package.json"}},
+	}, detections)
+}
+
+func TestLoadDetectionRulesShouldReturnAnErrorForAnInvalidContentPattern(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"rules.yaml": `rules:
+  - glob: package.json
+    content: '('
+    option: Node
+`,
+	})
+
+	_, err := internal.LoadDetectionRules([]string{filepath.Join(testDir, "rules.yaml")})
+
+	require.Error(t, err)
+}
+
+func TestLoadDetectionRulesShouldReturnAnErrorForARuleWithoutAnOption(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"rules.yaml": `rules:
+  - glob: go.mod
+`,
+	})
+
+	_, err := internal.LoadDetectionRules([]string{filepath.Join(testDir, "rules.yaml")})
+
+	require.Error(t, err)
+}
@@ -0,0 +1,176 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// maxDetectionFileSize is how much of a file is read when matching its
+// contents against a detection rule.
+const maxDetectionFileSize = 1 << 20
+
+// Detection is an option that was detected in a project.
+type Detection struct {
+	Option string
+
+	// Markers are the paths of the files that suggested the option
+	// relative to the directory that was scanned.
+	Markers []string
+}
+
+// DetectOptions scans a directory tree for the marker files in the
+// given rules and returns the options they suggest sorted by name.
+func DetectOptions(root string, rules []DetectionRule) ([]Detection, error) {
+	markers := map[string][]string{}
+
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if filePath == root {
+			return nil
+		}
+
+		if entry.IsDir() && skipDetectionDir(entry.Name()) {
+			return filepath.SkipDir
+		}
+
+		relativePath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return fmt.Errorf("unable to resolve %s: %w", filePath, err)
+		}
+
+		name := entry.Name()
+		relativePath = filepath.ToSlash(relativePath)
+
+		if entry.IsDir() {
+			name += "/"
+			relativePath += "/"
+		}
+
+		matched := false
+
+		for _, rule := range rules {
+			ok, err := rule.matches(filePath, name)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				markers[rule.Option] = append(markers[rule.Option], relativePath)
+				matched = true
+			}
+		}
+
+		// There's no need to look inside of tool directories like
+		// .idea since they were already detected.
+		if matched && entry.IsDir() {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan %s: %w", root, err)
+	}
+
+	detections := make([]Detection, 0, len(markers))
+	for option, optionMarkers := range markers {
+		detections = append(detections, Detection{Option: option, Markers: optionMarkers})
+	}
+
+	slices.SortFunc(detections, func(a, b Detection) int {
+		return strings.Compare(strings.ToLower(a.Option), strings.ToLower(b.Option))
+	})
+
+	return detections, nil
+}
+
+// matches reports whether the rule matches the given file. Names of
+// directories end with a slash.
+func (rule DetectionRule) matches(filePath string, name string) (bool, error) {
+	if ok, _ := path.Match(rule.Glob, name); !ok {
+		return false, nil
+	}
+
+	if rule.content == nil {
+		return true, nil
+	}
+
+	if strings.HasSuffix(name, "/") {
+		return false, nil
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false, fmt.Errorf("unable to open %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	contents, err := io.ReadAll(io.LimitReader(file, maxDetectionFileSize))
+	if err != nil {
+		return false, fmt.Errorf("unable to read %s: %w", filePath, err)
+	}
+
+	return rule.content.Match(contents), nil
+}
+
+// skipDetectionDir reports whether a directory is skipped when
+// scanning for marker files. These are either git's own data or
+// dependencies that contain marker files of their own.
+func skipDetectionDir(name string) bool {
+	switch name {
+	case ".git", ".venv", "__pycache__", "node_modules", "vendor", "venv":
+		return true
+	default:
+		return false
+	}
+}
+
+// Detect scans a directory tree for marker files and returns the
+// options they suggest. Only options that are provided by one of the
+// client's adapters are returned, using the name the highest priority
+// adapter lists them under.
+func (client *Client) Detect(root string) ([]Detection, error) {
+	rules := client.DetectionRules
+	if rules == nil {
+		defaultRules, err := DefaultDetectionRules()
+		if err != nil {
+			return nil, err
+		}
+
+		rules = defaultRules
+	}
+
+	detections, err := DetectOptions(root, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	lister := client.newOptionLister()
+	available := []Detection{}
+
+	for _, detection := range detections {
+		for index := range client.Adapters {
+			option, ok := lister.match(index, []string{detection.Option})
+			if ok {
+				available = append(available, Detection{Option: option, Markers: detection.Markers})
+
+				break
+			}
+		}
+	}
+
+	if len(detections) > 0 && !lister.anyListed() {
+		return nil, fmt.Errorf("unable to retrieve option list:\n%s", lister.errors)
+	}
+
+	return available, nil
+}
@@ -0,0 +1,101 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func writeProjectFiles(t *testing.T, root string, files ...string) {
+	t.Helper()
+
+	for _, file := range files {
+		filePath := filepath.Join(root, filepath.FromSlash(file))
+
+		err := os.MkdirAll(filepath.Dir(filePath), 0o750)
+		require.NoError(t, err)
+
+		err = os.WriteFile(filePath, []byte{}, 0o600)
+		require.NoError(t, err)
+	}
+}
+
+func defaultDetectionRules(t *testing.T) []internal.DetectionRule {
+	t.Helper()
+
+	rules, err := internal.DefaultDetectionRules()
+	require.NoError(t, err)
+
+	return rules
+}
+
+func TestDetectOptionsShouldFindMarkerFiles(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeProjectFiles(t, root, "go.mod", "web/package.json", "infra/main.tf", ".idea/workspace.xml")
+
+	detections, err := internal.DetectOptions(root, defaultDetectionRules(t))
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.Detection{
+		{Option: "Go", Markers: []string{"go.mod"}},
+		{Option: "JetBrains", Markers: []string{".idea/"}},
+		{Option: "Node", Markers: []string{"web/package.json"}},
+		{Option: "Terraform", Markers: []string{"infra/main.tf"}},
+	}, detections)
+}
+
+func TestDetectOptionsShouldSkipDependencies(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeProjectFiles(t, root, "package.json", "node_modules/left-pad/package.json", "vendor/example/go.mod")
+
+	detections, err := internal.DetectOptions(root, defaultDetectionRules(t))
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.Detection{
+		{Option: "Node", Markers: []string{"package.json"}},
+	}, detections)
+}
+
+func TestDetectOptionsShouldMatchDirectoryPatternsOnlyAgainstDirectories(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeProjectFiles(t, root, ".vscode", "App.xcodeproj/project.pbxproj")
+
+	detections, err := internal.DetectOptions(root, defaultDetectionRules(t))
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.Detection{
+		{Option: "Xcode", Markers: []string{"App.xcodeproj/"}},
+	}, detections)
+}
+
+func TestClientDetectShouldOnlyReturnAvailableOptions(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeProjectFiles(t, root, "go.mod", "Cargo.toml")
+
+	primaryAdapter := newFakeAdapter("primary")
+	primaryAdapter.addListReturn([]string{"go", "Python"}, nil)
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{&primaryAdapter},
+		Aliases:  nil,
+	}
+
+	detections, err := client.Detect(root)
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.Detection{
+		{Option: "go", Markers: []string{"go.mod"}},
+	}, detections)
+}
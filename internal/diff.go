@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContextLines = 3
+
+// diffOperation is a single line of a diff. Kind is ' ' for lines
+// both sides have in common, '-' for removed lines and '+' for added
+// lines.
+type diffOperation struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff returns the differences between two files in the
+// unified diff format, or an empty string if they're the same.
+func UnifiedDiff(oldName string, newName string, oldContents string, newContents string) string {
+	if oldContents == newContents {
+		return ""
+	}
+
+	operations := diffLines(splitLines(oldContents), splitLines(newContents))
+
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", oldName, newName))
+
+	for _, hunk := range diffHunks(operations) {
+		writeHunk(&builder, operations, hunk[0], hunk[1])
+	}
+
+	return builder.String()
+}
+
+// splitLines splits text into lines, keeping the line endings so that
+// a missing newline at the end of the file shows up in the diff.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// diffLines finds the shortest set of removals and additions that
+// turns the old lines into the new lines using their longest common
+// subsequence.
+func diffLines(oldLines []string, newLines []string) []diffOperation {
+	// common[row][column] is the length of the longest common
+	// subsequence of oldLines[row:] and newLines[column:].
+	common := make([][]int, len(oldLines)+1)
+	for row := range common {
+		common[row] = make([]int, len(newLines)+1)
+	}
+
+	for row := len(oldLines) - 1; row >= 0; row-- {
+		for column := len(newLines) - 1; column >= 0; column-- {
+			if oldLines[row] == newLines[column] {
+				common[row][column] = common[row+1][column+1] + 1
+			} else {
+				common[row][column] = max(common[row+1][column], common[row][column+1])
+			}
+		}
+	}
+
+	operations := make([]diffOperation, 0, len(oldLines)+len(newLines))
+	row, column := 0, 0
+
+	for row < len(oldLines) || column < len(newLines) {
+		switch {
+		case row < len(oldLines) && column < len(newLines) && oldLines[row] == newLines[column]:
+			operations = append(operations, diffOperation{kind: ' ', line: oldLines[row]})
+			row++
+			column++
+
+		case column >= len(newLines) || (row < len(oldLines) && common[row+1][column] >= common[row][column+1]):
+			operations = append(operations, diffOperation{kind: '-', line: oldLines[row]})
+			row++
+
+		default:
+			operations = append(operations, diffOperation{kind: '+', line: newLines[column]})
+			column++
+		}
+	}
+
+	return operations
+}
+
+// diffHunks groups the changed lines into hunks with some surrounding
+// context. Each hunk is the start and end (exclusive) of its
+// operations.
+func diffHunks(operations []diffOperation) [][2]int {
+	hunks := [][2]int{}
+
+	for position, operation := range operations {
+		if operation.kind == ' ' {
+			continue
+		}
+
+		start := max(0, position-diffContextLines)
+		end := min(len(operations), position+diffContextLines+1)
+
+		if len(hunks) > 0 && start <= hunks[len(hunks)-1][1] {
+			hunks[len(hunks)-1][1] = end
+
+			continue
+		}
+
+		hunks = append(hunks, [2]int{start, end})
+	}
+
+	return hunks
+}
+
+func writeHunk(builder *strings.Builder, operations []diffOperation, start int, end int) {
+	oldStart, newStart := 1, 1
+
+	for _, operation := range operations[:start] {
+		if operation.kind != '+' {
+			oldStart++
+		}
+
+		if operation.kind != '-' {
+			newStart++
+		}
+	}
+
+	oldCount, newCount := 0, 0
+
+	for _, operation := range operations[start:end] {
+		if operation.kind != '+' {
+			oldCount++
+		}
+
+		if operation.kind != '-' {
+			newCount++
+		}
+	}
+
+	builder.WriteString(fmt.Sprintf(
+		"@@ -%s +%s @@\n",
+		hunkRange(oldStart, oldCount),
+		hunkRange(newStart, newCount),
+	))
+
+	for _, operation := range operations[start:end] {
+		builder.WriteByte(operation.kind)
+		builder.WriteString(operation.line)
+
+		if !strings.HasSuffix(operation.line, "\n") {
+			builder.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
+
+// hunkRange formats the lines covered by one side of a hunk. Empty
+// ranges refer to the line before where the lines would be.
+func hunkRange(start int, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	default:
+		return fmt.Sprintf("%d,%d", start, count)
+	}
+}
@@ -0,0 +1,68 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestUnifiedDiffShouldBeEmptyWhenNothingChanged(t *testing.T) {
+	t.Parallel()
+
+	diff := internal.UnifiedDiff("a", "b", "*.o\n", "*.o\n")
+
+	require.Empty(t, diff)
+}
+
+func TestUnifiedDiffShouldShowChangedLinesWithContext(t *testing.T) {
+	t.Parallel()
+
+	oldContents := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	newContents := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n"
+
+	diff := internal.UnifiedDiff("a/.gitignore", "b/.gitignore", oldContents, newContents)
+
+	require.Equal(
+		t,
+		"--- a/.gitignore\n"+
+			"+++ b/.gitignore\n"+
+			"@@ -2,7 +2,7 @@\n"+
+			" 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		diff,
+	)
+}
+
+func TestUnifiedDiffShouldSplitDistantChangesIntoHunks(t *testing.T) {
+	t.Parallel()
+
+	oldContents := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	newContents := "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+
+	diff := internal.UnifiedDiff("a", "b", oldContents, newContents)
+
+	require.Equal(
+		t,
+		"--- a\n+++ b\n"+
+			"@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n"+
+			"@@ -10,3 +10,4 @@\n 10\n 11\n 12\n+13\n",
+		diff,
+	)
+}
+
+func TestUnifiedDiffShouldHandleEmptyFiles(t *testing.T) {
+	t.Parallel()
+
+	diff := internal.UnifiedDiff("a", "b", "", "*.o\n")
+
+	require.Equal(t, "--- a\n+++ b\n@@ -0,0 +1 @@\n+*.o\n", diff)
+}
+
+func TestUnifiedDiffShouldShowAMissingNewlineAtTheEndOfTheFile(t *testing.T) {
+	t.Parallel()
+
+	diff := internal.UnifiedDiff("a", "b", "*.o", "*.o\n")
+
+	require.Equal(t, "--- a\n+++ b\n@@ -1 +1 @@\n-*.o\n\\ No newline at end of file\n+*.o\n", diff)
+}
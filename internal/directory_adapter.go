@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// DirectoryAdapter is an adapter for pulling gitignore data from a
+// local directory of *.gitignore files.
+type DirectoryAdapter struct {
+	Name      string
+	Directory string
+
+	// SyncDirectory is an optional directory that templates are
+	// copied from into Directory on update.
+	SyncDirectory string
+}
+
+// NewDirectoryAdapter creates a new adapter for working with a local
+// directory of templates.
+func NewDirectoryAdapter(name string, directory string) *DirectoryAdapter {
+	return &DirectoryAdapter{
+		Name:          name,
+		Directory:     directory,
+		SyncDirectory: "",
+	}
+}
+
+// SourceName returns the name of the source this adapter pulls
+// templates from.
+func (adapter *DirectoryAdapter) SourceName() string {
+	return adapter.Name
+}
+
+// List returns the list of options that can be used to generate a
+// gitignore file.
+func (adapter *DirectoryAdapter) List() ([]string, error) {
+	index, err := buildTemplateIndex(adapter.Directory, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template directory: %w", err)
+	}
+
+	return index.names(), nil
+}
+
+// ListPaths returns the path of every template in the directory
+// without the file extension.
+func (adapter *DirectoryAdapter) ListPaths() ([]string, error) {
+	index, err := buildTemplateIndex(adapter.Directory, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template directory: %w", err)
+	}
+
+	return index.paths, nil
+}
+
+// Generate creates a gitignore file with the given options.
+func (adapter *DirectoryAdapter) Generate(options []string) (string, error) {
+	if len(options) == 0 {
+		return "", errors.New("must give at least one option")
+	}
+
+	index, err := buildTemplateIndex(adapter.Directory, false)
+	if err != nil {
+		return "", fmt.Errorf("unable to read template directory: %w", err)
+	}
+
+	return renderTemplates(directoryTemplateReader(adapter.Directory), index, options)
+}
+
+// Describe returns where the template for the given option came from.
+func (adapter *DirectoryAdapter) Describe(option string) (TemplateInfo, error) {
+	index, err := buildTemplateIndex(adapter.Directory, false)
+	if err != nil {
+		return TemplateInfo{Path: "", URL: "", Revision: ""}, fmt.Errorf("unable to read template directory: %w", err)
+	}
+
+	templatePath, err := index.resolve(option)
+	if err != nil {
+		return TemplateInfo{Path: "", URL: "", Revision: ""}, err
+	}
+
+	return TemplateInfo{
+		Path:     templatePath,
+		URL:      adapter.Directory,
+		Revision: "",
+	}, nil
+}
+
+// Update copies templates from the sync directory, if one is
+// configured. Otherwise the directory is used as is and there is
+// nothing to update.
+func (adapter *DirectoryAdapter) Update() error {
+	if adapter.SyncDirectory == "" {
+		return nil
+	}
+
+	err := filepath.Walk(adapter.SyncDirectory, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("unable to read sync directory: %w", err)
+		}
+
+		if info.IsDir() || path.Ext(filePath) != templateExtension {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(adapter.SyncDirectory, filePath)
+		if err != nil {
+			return fmt.Errorf("unable to determine relative path of %s: %w", filePath, err)
+		}
+
+		return copyFile(filePath, filepath.Join(adapter.Directory, relativePath))
+	})
+
+	if err != nil {
+		return fmt.Errorf("unable to sync templates from %s: %w", adapter.SyncDirectory, err)
+	}
+
+	return nil
+}
+
+func copyFile(source string, destination string) error {
+	contents, err := os.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %w", source, err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(destination), 0o750)
+	if err != nil {
+		return fmt.Errorf("unable to create directory for %s: %w", destination, err)
+	}
+
+	err = os.WriteFile(destination, contents, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to write %s: %w", destination, err)
+	}
+
+	return nil
+}
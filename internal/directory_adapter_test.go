@@ -0,0 +1,170 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func writeTemplates(t testing.TB, directory string, templates map[string]string) {
+	t.Helper()
+
+	for name, content := range templates {
+		filePath := filepath.Join(directory, name)
+
+		err := os.MkdirAll(filepath.Dir(filePath), 0o750)
+		require.NoError(t, err)
+
+		err = os.WriteFile(filePath, []byte(content), 0o600)
+		require.NoError(t, err)
+	}
+}
+
+func TestDirectoryAdapterListShouldRetrieveAListOfOptions(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"Bazel-Internal.gitignore": "bazel-*\n",
+		"editors/Emacs.gitignore":  "*~\n",
+		"README.md":                "Not a template\n",
+	})
+
+	adapter := internal.NewDirectoryAdapter("internal", testDir)
+
+	options, err := adapter.List()
+
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"Bazel-Internal", "Emacs"}, options)
+}
+
+func TestDirectoryAdapterListShouldReturnAnErrorIfTheDirectoryDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	adapter := internal.NewDirectoryAdapter("internal", filepath.Join(t.TempDir(), "missing"))
+
+	_, err := adapter.List()
+
+	require.Error(t, err)
+}
+
+func TestDirectoryAdapterGenerateShouldCreateAGitignoreFile(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"Bazel-Internal.gitignore": "bazel-*\n",
+		"editors/Emacs.gitignore":  "*~\n",
+	})
+
+	adapter := internal.NewDirectoryAdapter("internal", testDir)
+
+	contents, err := adapter.Generate([]string{"Bazel-Internal", "Emacs"})
+
+	require.NoError(t, err)
+	require.Contains(t, contents, "### Bazel-Internal ###\nbazel-*\n")
+	require.Contains(t, contents, "### Emacs ###\n*~\n")
+}
+
+func TestDirectoryAdapterGenerateShouldReturnAnErrorWhenGivenAnInvalidOption(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"Bazel-Internal.gitignore": "bazel-*\n",
+	})
+
+	adapter := internal.NewDirectoryAdapter("internal", testDir)
+
+	_, err := adapter.Generate([]string{"iaminvalid"})
+
+	require.Error(t, err)
+}
+
+func TestDirectoryAdapterUpdateShouldDoNothingWithoutASyncDirectory(t *testing.T) {
+	t.Parallel()
+
+	adapter := internal.NewDirectoryAdapter("internal", t.TempDir())
+
+	err := adapter.Update()
+
+	require.NoError(t, err)
+}
+
+func TestDirectoryAdapterUpdateShouldCopyTemplatesFromTheSyncDirectory(t *testing.T) {
+	t.Parallel()
+
+	syncDir := t.TempDir()
+	writeTemplates(t, syncDir, map[string]string{
+		"Bazel-Internal.gitignore": "bazel-*\n",
+		"editors/Emacs.gitignore":  "*~\n",
+		"README.md":                "Not a template\n",
+	})
+
+	testDir := t.TempDir()
+	adapter := internal.NewDirectoryAdapter("internal", testDir)
+	adapter.SyncDirectory = syncDir
+
+	err := adapter.Update()
+	require.NoError(t, err)
+
+	require.FileExists(t, filepath.Join(testDir, "Bazel-Internal.gitignore"))
+	require.FileExists(t, filepath.Join(testDir, "editors", "Emacs.gitignore"))
+	require.NoFileExists(t, filepath.Join(testDir, "README.md"))
+}
+
+func TestDirectoryAdapterListPathsShouldKeepNestedDirectories(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"Bazel-Internal.gitignore": "bazel-*\n",
+		"editors/Emacs.gitignore":  "*~\n",
+	})
+
+	adapter := internal.NewDirectoryAdapter("internal", testDir)
+
+	templatePaths, err := adapter.ListPaths()
+
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"Bazel-Internal", "editors/Emacs"}, templatePaths)
+}
+
+func TestDirectoryAdapterGenerateShouldAcceptTemplatePaths(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"Emacs.gitignore":         "# top level\n",
+		"editors/Emacs.gitignore": "*~\n",
+	})
+
+	adapter := internal.NewDirectoryAdapter("internal", testDir)
+
+	contents, err := adapter.Generate([]string{"editors/Emacs"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### Emacs ###\n*~\n\n", contents)
+}
+
+func TestDirectoryAdapterGenerateShouldMatchOptionsWithoutRegardToCase(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"Bazel-Internal.gitignore": "bazel-*\n",
+		"editors/Emacs.gitignore":  "*~\n",
+	})
+
+	adapter := internal.NewDirectoryAdapter("internal", testDir)
+
+	contents, err := adapter.Generate([]string{"bazel-internal", "EDITORS/emacs"})
+
+	require.NoError(t, err)
+	require.Contains(t, contents, "### Bazel-Internal ###\nbazel-*\n")
+	require.Contains(t, contents, "### Emacs ###\n*~\n")
+}
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"strings"
+
+	"github.com/durandj/git-ignore/internal/gitignore"
+)
+
+// Explanation describes the pattern that decides whether a path is
+// ignored and where it came from.
+type Explanation struct {
+	Match *gitignore.MatchResult
+
+	// BlockOptions are the options of the git-ignore block the pattern
+	// is in. It's empty if the pattern was written by hand.
+	BlockOptions []string
+
+	// Locked is true if the pattern is in a git-ignore block that has a
+	// lockfile. The global and exclude files never have one.
+	Locked bool
+
+	// Template is the locked template the pattern was generated from.
+	// It's nil if the pattern was written by hand, the block has no
+	// lockfile or it doesn't match its lockfile anymore.
+	Template *LockedTemplate
+}
+
+// Ignored reports whether the path is ignored.
+func (explanation *Explanation) Ignored() bool {
+	return explanation.Match.Ignored()
+}
+
+// Explain returns the pattern that decides whether the path is ignored
+// or nil if no pattern matches it. The path uses slashes and is
+// relative to the root of the repository. Patterns in a git-ignore
+// block are traced back to the template they were generated from.
+func (rules *IgnoreRules) Explain(filePath string, isDir bool) (*Explanation, error) {
+	match, err := rules.Match(filePath, isDir)
+	if err != nil || match == nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{
+		Match:        match,
+		BlockOptions: []string{},
+		Locked:       false,
+		Template:     nil,
+	}
+
+	contents, err := ReadIgnoreFile(match.File.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(contents, "\n")
+
+	start, end, err := findManagedBlockLines(lines)
+	if err != nil || start < 0 {
+		// Ignore files with a malformed block are still matched by git
+		// so their patterns are treated as written by hand.
+		return explanation, nil //nolint:nilerr // See above
+	}
+
+	// Pattern lines start from 1 while the block's markers start
+	// from 0.
+	blockLine := match.Pattern.Line - 1 - (start + 1)
+	if blockLine < 0 || blockLine >= end-start-1 {
+		return explanation, nil
+	}
+
+	explanation.BlockOptions = parseManagedBlockStart(lines[start])
+
+	lockfile, err := ReadLockfile(LockfilePath(match.File.Source))
+	if err != nil || lockfile == nil {
+		return explanation, nil //nolint:nilerr // The block can still be explained without a lockfile
+	}
+
+	explanation.Locked = true
+	explanation.Template = attributeBlockLine(lines[start+1:end], lockfile.Templates, blockLine)
+
+	return explanation, nil
+}
+
+// attributeBlockLine finds the locked template that generated the
+// given line of a git-ignore block. The block is split back into the
+// sections it was rendered from by matching them against the hashes
+// in the lockfile so it returns nil if the block was edited by hand.
+func attributeBlockLine(blockLines []string, templates []LockedTemplate, line int) *LockedTemplate {
+	sectionEnds := make([]int, 0, len(templates))
+	sectionStart := 0
+
+	for index, template := range templates {
+		last := index == len(templates)-1
+
+		sectionEnd, ok := findSectionEnd(blockLines, sectionStart, template.Hash, last)
+		if !ok {
+			return nil
+		}
+
+		sectionEnds = append(sectionEnds, sectionEnd)
+		sectionStart = sectionEnd
+	}
+
+	// Anything after the last section was added by hand.
+	if sectionStart != len(blockLines) {
+		return nil
+	}
+
+	for index, sectionEnd := range sectionEnds {
+		if line < sectionEnd {
+			return &templates[index]
+		}
+	}
+
+	return nil
+}
+
+// findSectionEnd finds the line after the end of the section that
+// starts at the given line and has the given hash. The last section
+// may have had its trailing blank line trimmed from the end of the
+// block.
+func findSectionEnd(blockLines []string, start int, hash string, last bool) (int, bool) {
+	var section strings.Builder
+
+	for end := start; end < len(blockLines); end++ {
+		section.WriteString(blockLines[end])
+		section.WriteString("\n")
+
+		// Sections that don't end with a newline get one when they are
+		// rendered.
+		content := section.String()
+		candidates := []string{content, strings.TrimSuffix(content, "\n")}
+
+		if last {
+			candidates = append(candidates, content+"\n")
+		}
+
+		for _, candidate := range candidates {
+			if hashContent([]byte(candidate)) == hash {
+				return end + 1, true
+			}
+		}
+	}
+
+	return 0, false
+}
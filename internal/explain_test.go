@@ -0,0 +1,154 @@
+package internal_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+// writeGeneratedIgnoreFile writes a .gitignore file with a git-ignore
+// block for the given sections after the hand written rules, along
+// with its lockfile.
+func writeGeneratedIgnoreFile(t *testing.T, repoDir string, handWritten string, sections []internal.Section) {
+	t.Helper()
+
+	options := []string{}
+	for _, section := range sections {
+		options = append(options, section.Option)
+	}
+
+	contents, err := internal.MergeManagedBlock(handWritten, options, internal.RenderSections(sections))
+	require.NoError(t, err)
+
+	ignorePath := filepath.Join(repoDir, internal.IgnoreFileName)
+	writeTemplates(t, repoDir, map[string]string{internal.IgnoreFileName: contents})
+
+	err = internal.NewLockfile(sections).Write(internal.LockfilePath(ignorePath))
+	require.NoError(t, err)
+}
+
+func newSection(option string, content string) internal.Section {
+	return internal.Section{
+		Option: option,
+		Source: "github",
+		Template: internal.TemplateInfo{
+			Path:     option + ".gitignore",
+			URL:      "https://github.com/github/gitignore.git",
+			Revision: "0123456789abcdef",
+		},
+		Content: content,
+	}
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestIgnoreRulesExplainShouldAttributeGeneratedPatternsToTheirTemplate(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := t.TempDir()
+
+	writeGeneratedIgnoreFile(t, repoDir, "/local/\n", []internal.Section{
+		newSection("Go", "### Go ###\n*.test\nvendor/\n\n"),
+		newSection("Node", "### Node ###\nnode_modules/\n*.log\n\n"),
+	})
+
+	rules, err := internal.LoadIgnoreRules(repoDir)
+	require.NoError(t, err)
+
+	explanation, err := rules.Explain("web/node_modules/react/index.js", false)
+	require.NoError(t, err)
+	require.NotNil(t, explanation)
+	require.True(t, explanation.Ignored())
+	require.Equal(t, "node_modules/", explanation.Match.Pattern.Text)
+	require.Equal(t, "web/node_modules", explanation.Match.Path)
+	require.Equal(t, []string{"Go", "Node"}, explanation.BlockOptions)
+	require.NotNil(t, explanation.Template)
+	require.Equal(t, "Node", explanation.Template.Option)
+	require.True(t, explanation.Locked)
+	require.Equal(t, "Node.gitignore", explanation.Template.Path)
+
+	explanation, err = rules.Explain("pkg/server.test", false)
+	require.NoError(t, err)
+	require.NotNil(t, explanation.Template)
+	require.Equal(t, "Go", explanation.Template.Option)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestIgnoreRulesExplainShouldNotAttributeHandWrittenPatterns(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := t.TempDir()
+
+	writeGeneratedIgnoreFile(t, repoDir, "/local/\n", []internal.Section{
+		newSection("Go", "### Go ###\n*.test\n"),
+	})
+
+	rules, err := internal.LoadIgnoreRules(repoDir)
+	require.NoError(t, err)
+
+	explanation, err := rules.Explain("local", true)
+	require.NoError(t, err)
+	require.NotNil(t, explanation)
+	require.Equal(t, 1, explanation.Match.Pattern.Line)
+	require.Empty(t, explanation.BlockOptions)
+	require.Nil(t, explanation.Template)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestIgnoreRulesExplainShouldNotAttributeEditedBlocks(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := t.TempDir()
+
+	writeGeneratedIgnoreFile(t, repoDir, "", []internal.Section{
+		newSection("Go", "### Go ###\n*.test\n"),
+	})
+
+	writeTemplates(t, repoDir, map[string]string{
+		internal.IgnoreFileName: "# >>> git-ignore: Go >>>\n### Go ###\n*.test\n*.out\n# <<< git-ignore <<<\n",
+	})
+
+	rules, err := internal.LoadIgnoreRules(repoDir)
+	require.NoError(t, err)
+
+	explanation, err := rules.Explain("main.test", false)
+	require.NoError(t, err)
+	require.NotNil(t, explanation)
+	require.Equal(t, []string{"Go"}, explanation.BlockOptions)
+	require.True(t, explanation.Locked)
+	require.Nil(t, explanation.Template)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestIgnoreRulesExplainShouldExplainBlocksWithoutALockfile(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := t.TempDir()
+
+	writeTemplates(t, repoDir, map[string]string{
+		internal.IgnoreFileName: "# >>> git-ignore: macOS >>>\n### macOS ###\n.DS_Store\n# <<< git-ignore <<<\n",
+	})
+
+	rules, err := internal.LoadIgnoreRules(repoDir)
+	require.NoError(t, err)
+
+	explanation, err := rules.Explain(".DS_Store", false)
+	require.NoError(t, err)
+	require.NotNil(t, explanation)
+	require.Equal(t, []string{"macOS"}, explanation.BlockOptions)
+	require.False(t, explanation.Locked)
+	require.Nil(t, explanation.Template)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestIgnoreRulesExplainShouldReturnNilWhenNothingMatches(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := t.TempDir()
+
+	writeTemplates(t, repoDir, map[string]string{internal.IgnoreFileName: "*.o\n"})
+
+	rules, err := internal.LoadIgnoreRules(repoDir)
+	require.NoError(t, err)
+
+	explanation, err := rules.Explain("main.go", false)
+	require.NoError(t, err)
+	require.Nil(t, explanation)
+}
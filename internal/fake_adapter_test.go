@@ -25,6 +25,7 @@ type updateReturnValue struct {
 }
 
 type fakeAdapter struct {
+	name                 string
 	listCalls            []listCall
 	listReturnValues     []listReturnValue
 	generateCalls        []generateCall
@@ -33,6 +34,10 @@ type fakeAdapter struct {
 	updateReturnValues   []updateReturnValue
 }
 
+func (adapter *fakeAdapter) SourceName() string {
+	return adapter.name
+}
+
 func (adapter *fakeAdapter) Update() error {
 	adapter.updateCalls = append(adapter.updateCalls, updateCall{})
 
@@ -76,6 +81,14 @@ func (adapter *fakeAdapter) addGenerateReturn(content string, err error) {
 	})
 }
 
+func (adapter *fakeAdapter) getListCalls() []listCall {
+	return adapter.listCalls
+}
+
+func (adapter *fakeAdapter) getGenerateCalls() []generateCall {
+	return adapter.generateCalls
+}
+
 func (adapter *fakeAdapter) getUpdateCalls() []updateCall {
 	return adapter.updateCalls
 }
@@ -86,8 +99,9 @@ func (adapter *fakeAdapter) addUpdateReturn(err error) {
 	})
 }
 
-func newFakeAdapter() fakeAdapter {
+func newFakeAdapter(name string) fakeAdapter {
 	return fakeAdapter{
+		name:                 name,
 		listCalls:            []listCall{},
 		listReturnValues:     []listReturnValue{},
 		generateCalls:        []generateCall{},
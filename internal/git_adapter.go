@@ -1,28 +1,38 @@
 package internal
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"os/user"
 	"path"
-	"path/filepath"
-	"slices"
 	"strings"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
 // DefaultGitRepo is the default repository to use for gitignore files.
 const DefaultGitRepo string = "https://github.com/github/gitignore.git"
 
+// DefaultGitSourceName is the default name for the git source.
+const DefaultGitSourceName string = "github"
+
 // GitAdapter is an adapter for pulling gitignore data from a git
 // repository.
 type GitAdapter struct {
+	Name          string
 	RepoDirectory string
 	RepoURL       string
+
+	index *templateIndex
+
+	// pinnedTree is the tree of the commit templates are read from
+	// when the adapter is pinned to a revision. Templates are read from
+	// the working tree when it is nil.
+	pinnedTree *object.Tree
 }
 
 // NewGitAdapter creates a new adapter for working with Git
@@ -36,76 +46,236 @@ func NewGitAdapter() (*GitAdapter, error) {
 	userHome := currentUser.HomeDir
 
 	return &GitAdapter{
+		Name:          DefaultGitSourceName,
 		RepoDirectory: path.Join(userHome, ".local", "share", "git-ignore", "gitignore"),
 		RepoURL:       DefaultGitRepo,
+		index:         nil,
+		pinnedTree:    nil,
 	}, nil
 }
 
+// SourceName returns the name of the source this adapter pulls
+// templates from.
+func (adapter *GitAdapter) SourceName() string {
+	return adapter.Name
+}
+
 // List returns the list of options that can be used to generate a
 // gitignore file.
 func (adapter *GitAdapter) List() ([]string, error) {
-	options := []string{}
+	index, err := adapter.loadIndex()
+	if err != nil {
+		return nil, err
+	}
 
-	err := filepath.Walk(adapter.RepoDirectory, func(filePath string, info os.FileInfo, err error) error {
-		if err != nil {
-			return fmt.Errorf("unable to file gitignore files: %w", err)
-		}
+	return index.names(), nil
+}
+
+// ListPaths returns the path of every template in the repository
+// without the file extension.
+func (adapter *GitAdapter) ListPaths() ([]string, error) {
+	index, err := adapter.loadIndex()
+	if err != nil {
+		return nil, err
+	}
 
-		if path.Ext(filePath) != ".gitignore" {
+	return index.paths, nil
+}
+
+// Generate creates a gitignore file with the given options.
+func (adapter *GitAdapter) Generate(options []string) (string, error) {
+	if len(options) == 0 {
+		return "", errors.New("must give at least one option")
+	}
+
+	index, err := adapter.loadIndex()
+	if err != nil {
+		return "", err
+	}
+
+	return renderTemplates(adapter.readTemplate, index, options)
+}
+
+// Pin makes the adapter read templates as they were at the given
+// revision, e.g. a commit hash, tag or branch, rather than from the
+// working tree. Templates are read straight from the repository's
+// object store so the result doesn't depend on when the repository
+// was last updated. An empty revision unpins the adapter.
+func (adapter *GitAdapter) Pin(revision string) error {
+	adapter.index = nil
+	adapter.pinnedTree = nil
+
+	if revision == "" {
+		return nil
+	}
+
+	repository, err := git.PlainOpen(adapter.RepoDirectory)
+	if err != nil {
+		return fmt.Errorf("unable to open repository: %w", err)
+	}
+
+	hash, err := repository.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return fmt.Errorf(
+			"unable to find revision %s in %s, try running git ignore update: %w",
+			revision,
+			adapter.RepoURL,
+			err,
+		)
+	}
+
+	commit, err := repository.CommitObject(*hash)
+	if err != nil {
+		return fmt.Errorf("unable to read commit %s: %w", hash, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return fmt.Errorf("unable to read tree for commit %s: %w", hash, err)
+	}
+
+	templates := []indexedTemplate{}
+
+	err = tree.Files().ForEach(func(file *object.File) error {
+		if path.Ext(file.Name) != templateExtension {
 			return nil
 		}
 
-		option := path.Base(strings.Replace(filePath, ".gitignore", "", 1))
-		options = append(options, option)
+		templatePath := strings.TrimSuffix(file.Name, templateExtension)
+		templates = append(templates, indexedTemplate{
+			Name: path.Base(templatePath),
+			Path: templatePath,
+			Hash: "",
+			Size: file.Size,
+		})
 
 		return nil
 	})
+	if err != nil {
+		return fmt.Errorf("unable to read templates for commit %s: %w", hash, err)
+	}
+
+	adapter.index = newTemplateIndex(templates, hash.String())
+	adapter.pinnedTree = tree
 
+	return nil
+}
+
+// readTemplate reads a template from the pinned commit if there is one
+// and the working tree otherwise.
+func (adapter *GitAdapter) readTemplate(templatePath string) ([]byte, error) {
+	if adapter.pinnedTree == nil {
+		return directoryTemplateReader(adapter.RepoDirectory)(templatePath)
+	}
+
+	file, err := adapter.pinnedTree.File(templatePath + templateExtension)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find %s: %w", templatePath, err)
+	}
+
+	contents, err := file.Contents()
 	if err != nil {
-		return nil, fmt.Errorf("unable to read gitignore repository: %w", err)
+		return nil, fmt.Errorf("unable to read %s: %w", templatePath, err)
 	}
 
-	return options, nil
+	return []byte(contents), nil
 }
 
-// Generate creates a gitignore file with the given options.
-func (adapter *GitAdapter) Generate(options []string) (string, error) {
-	if len(options) == 0 {
-		return "", errors.New("must give at least one option")
+// Describe returns where the template for the given option came from.
+func (adapter *GitAdapter) Describe(option string) (TemplateInfo, error) {
+	index, err := adapter.loadIndex()
+	if err != nil {
+		return TemplateInfo{Path: "", URL: "", Revision: ""}, err
 	}
 
-	validOptions, err := adapter.List()
+	templatePath, err := index.resolve(option)
 	if err != nil {
-		return "", fmt.Errorf("unable to validate options for generating ignore file: %w", err)
+		return TemplateInfo{Path: "", URL: "", Revision: ""}, err
 	}
 
-	for _, option := range options {
-		if !slices.Contains(validOptions, option) {
-			return "", fmt.Errorf("invalid option \"%s\"", option)
-		}
+	return TemplateInfo{
+		Path:     templatePath,
+		URL:      adapter.RepoURL,
+		Revision: index.revision,
+	}, nil
+}
+
+// Changes returns the commits that changed the template at the given
+// path since the given revision, newest first. Commits that were
+// already part of the given revision's history are skipped.
+func (adapter *GitAdapter) Changes(templatePath string, since string) ([]TemplateChange, error) {
+	repository, err := git.PlainOpen(adapter.RepoDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open repository: %w", err)
 	}
 
-	var builder strings.Builder
-	for _, option := range options {
-		filePath, err := adapter.findOptionFile(option)
-		if err != nil {
-			return "", fmt.Errorf("unable to find file: %w", err)
-		}
+	sinceHash, err := repository.ResolveRevision(plumbing.Revision(since))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to find revision %s in %s, try running git ignore update: %w",
+			since,
+			adapter.RepoURL,
+			err,
+		)
+	}
 
-		contents, err := os.ReadFile(filePath)
-		if err != nil {
-			return "", fmt.Errorf("unable to read gitignore data for %s: %w", option, err)
-		}
+	head, err := repository.Head()
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve repository HEAD: %w", err)
+	}
+
+	seen := map[plumbing.Hash]bool{}
+
+	//nolint:exhaustruct // Only the starting commit is needed
+	sinceHistory, err := repository.Log(&git.LogOptions{From: *sinceHash})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read history of %s: %w", since, err)
+	}
+
+	err = sinceHistory.ForEach(func(commit *object.Commit) error {
+		seen[commit.Hash] = true
 
-		if !bytes.HasPrefix(contents, []byte("###")) {
-			builder.WriteString(fmt.Sprintf("### %s ###\n", option))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read history of %s: %w", since, err)
+	}
+
+	fileName := templatePath + templateExtension
+
+	//nolint:exhaustruct // Only the starting commit and file are needed
+	history, err := repository.Log(&git.LogOptions{From: head.Hash(), FileName: &fileName})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read history of %s: %w", templatePath, err)
+	}
+
+	changes := []TemplateChange{}
+
+	err = history.ForEach(func(commit *object.Commit) error {
+		if seen[commit.Hash] {
+			return storer.ErrStop
 		}
 
-		builder.Write(contents)
-		builder.WriteString("\n")
+		summary, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+		changes = append(changes, TemplateChange{
+			Revision: commit.Hash.String(),
+			Summary:  summary,
+			When:     commit.Author.When,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read history of %s: %w", templatePath, err)
 	}
 
-	return builder.String(), nil
+	return changes, nil
+}
+
+// IndexPath returns the location of the template index that is
+// refreshed on every update.
+func (adapter *GitAdapter) IndexPath() string {
+	return path.Join(adapter.RepoDirectory, ".git", "git-ignore-index.json")
 }
 
 // Update updates this plugin's local data.
@@ -123,7 +293,7 @@ func (adapter *GitAdapter) Update() error {
 			return fmt.Errorf("unable to clone repository: %w", err)
 		}
 
-		return nil
+		return adapter.refreshIndex()
 	}
 
 	repository, err := git.PlainOpen(adapter.RepoDirectory)
@@ -146,34 +316,76 @@ func (adapter *GitAdapter) Update() error {
 		return fmt.Errorf("unable to update gitignore repository: %w", err)
 	}
 
-	return nil
+	return adapter.refreshIndex()
 }
 
-func (adapter *GitAdapter) findOptionFile(option string) (string, error) {
-	filename := option + ".gitignore"
-	filePath := ""
+// loadIndex returns the template index, preferring the index saved
+// during the last update and falling back to walking the repository
+// if there isn't one.
+func (adapter *GitAdapter) loadIndex() (*templateIndex, error) {
+	if adapter.index != nil {
+		return adapter.index, nil
+	}
+
+	index, err := readTemplateIndex(adapter.IndexPath())
+	if err != nil {
+		return nil, err
+	}
 
-	err := filepath.Walk(adapter.RepoDirectory, func(currentFile string, fileInfo os.FileInfo, err error) error {
+	if index == nil {
+		index, err = buildTemplateIndex(adapter.RepoDirectory, false)
 		if err != nil {
-			return fmt.Errorf("error while looking for file: %w", err)
+			return nil, fmt.Errorf("unable to read gitignore repository: %w", err)
 		}
 
-		if strings.HasSuffix(currentFile, filename) {
-			filePath = currentFile
-
-			return io.EOF
+		index.revision, err = adapter.headRevision()
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		return nil
-	})
+	adapter.index = index
+
+	return index, nil
+}
+
+func (adapter *GitAdapter) refreshIndex() error {
+	adapter.index = nil
+	adapter.pinnedTree = nil
+
+	revision, err := adapter.headRevision()
+	if err != nil {
+		return err
+	}
+
+	index, err := buildTemplateIndex(adapter.RepoDirectory, true)
+	if err != nil {
+		return fmt.Errorf("unable to index gitignore repository: %w", err)
+	}
+
+	index.revision = revision
+
+	err = writeTemplateIndex(adapter.IndexPath(), index)
+	if err != nil {
+		return err
+	}
+
+	adapter.index = index
+
+	return nil
+}
 
-	if err != nil && !errors.Is(err, io.EOF) {
-		return "", fmt.Errorf("unable to find file for %s: %w", option, err)
+// headRevision returns the hash of the commit that is checked out.
+func (adapter *GitAdapter) headRevision() (string, error) {
+	repository, err := git.PlainOpen(adapter.RepoDirectory)
+	if err != nil {
+		return "", fmt.Errorf("unable to open repository: %w", err)
 	}
 
-	if filePath == "" {
-		return "", fmt.Errorf("unable to find file for %s", option)
+	head, err := repository.Head()
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve repository HEAD: %w", err)
 	}
 
-	return filePath, nil
+	return head.Hash().String(), nil
 }
@@ -1,11 +1,16 @@
 package internal_test
 
 import (
+	"errors"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/require"
 
 	"github.com/durandj/git-ignore/internal"
@@ -16,6 +21,7 @@ func TestGitAdapterListShouldRetrieveAListOfOptions(t *testing.T) {
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -35,6 +41,7 @@ func TestGitAdapterListShouldReturnAnErrorIfTheRepositoryDoesNotExist(t *testing
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -49,6 +56,7 @@ func TestGitAdapterGenerateShouldReturnAnErrorWhenNoOptionsAreGiven(t *testing.T
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -66,6 +74,7 @@ func TestGitAdapterGenerateShouldReturnAnErrorWhenTheRepositoryDoesNotExist(t *t
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -85,6 +94,7 @@ func TestGitAdapterGenerateShouldReturnAnErrorWhenGivenAnInvalidOption(t *testin
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -102,6 +112,7 @@ func TestGitAdapterGenerateShouldCreateAGitignoreFileWhenGivenASingleOption(t *t
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -120,6 +131,7 @@ func TestGitAdapterGenerateShouldCreateAGitignoreFileWhenGivenMultipleOptions(t
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -141,6 +153,7 @@ func TestGitAdapterGenerateShouldBeAbleToReadFromNestedDirectories(t *testing.T)
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -159,6 +172,7 @@ func TestGitAdapterGenerateShouldNotContainDuplicateHeaders(t *testing.T) {
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -177,6 +191,7 @@ func TestGitAdapterUpdateShouldPUllTheRepository(t *testing.T) {
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -193,6 +208,7 @@ func TestGitAdapterUpdateShouldReturnAnErrorWhenTheRepoDoesNotExist(t *testing.T
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: testDir,
 		RepoURL:       "https://example.com/thisdoes/notexist.git",
 	}
@@ -206,6 +222,7 @@ func TestGitAdapterUpdateShouldPullChangesToAnExistingRepo(t *testing.T) {
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -225,6 +242,7 @@ func TestGitAdapterUpdateShouldReturnAnErrorWhenThePathGivesAFileNotADir(t *test
 
 	testDir := t.TempDir()
 	adapter := &internal.GitAdapter{
+		Name:          "github",
 		RepoDirectory: path.Join(testDir, "gitignore"),
 		RepoURL:       internal.DefaultGitRepo,
 	}
@@ -240,3 +258,422 @@ func TestGitAdapterUpdateShouldReturnAnErrorWhenThePathGivesAFileNotADir(t *test
 	err = adapter.Update()
 	require.Error(t, err)
 }
+
+// newTemplateRepository creates a local git repository containing the
+// given templates that can be used in place of the upstream gitignore
+// repository.
+func newTemplateRepository(t *testing.T, templates map[string]string) string {
+	t.Helper()
+
+	repoDir := t.TempDir()
+
+	_, err := git.PlainInit(repoDir, false)
+	require.NoError(t, err)
+
+	commitTemplates(t, repoDir, templates)
+
+	return repoDir
+}
+
+// commitTemplates writes the given templates into the repository and
+// commits them, returning the new commit's hash.
+func commitTemplates(t testing.TB, repoDir string, templates map[string]string) string {
+	t.Helper()
+
+	writeTemplates(t, repoDir, templates)
+
+	repository, err := git.PlainOpen(repoDir)
+	require.NoError(t, err)
+
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+
+	//nolint:exhaustruct // Only All is needed
+	err = worktree.AddWithOptions(&git.AddOptions{All: true})
+	require.NoError(t, err)
+
+	//nolint:exhaustruct // Only the author is needed
+	hash, err := worktree.Commit("Update templates", &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "Test",
+			Email: "test@example.com",
+			When:  time.Now(),
+		},
+	})
+	require.NoError(t, err)
+
+	return hash.String()
+}
+
+func newLocalGitAdapter(t *testing.T, templates map[string]string) *internal.GitAdapter {
+	t.Helper()
+
+	adapter := &internal.GitAdapter{
+		Name:          "github",
+		RepoDirectory: path.Join(t.TempDir(), "gitignore"),
+		RepoURL:       newTemplateRepository(t, templates),
+	}
+
+	err := adapter.Update()
+	require.NoError(t, err)
+
+	return adapter
+}
+
+func TestGitAdapterGenerateShouldOnlyResolveExactTemplateNames(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"ObjectiveC.gitignore": "xcuserdata/\n",
+		"C.gitignore":          "*.o\n",
+	})
+
+	contents, err := adapter.Generate([]string{"C"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### C ###\n*.o\n\n", contents)
+}
+
+func TestGitAdapterListShouldNotIncludeAmbiguousNamesTwice(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"Global/Vim.gitignore":    "*.swp\n",
+		"community/Vim.gitignore": "*.swo\n",
+	})
+
+	options, err := adapter.List()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Vim"}, options)
+}
+
+func TestGitAdapterGenerateShouldReturnAnErrorForAmbiguousNames(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"Global/Vim.gitignore":    "*.swp\n",
+		"community/Vim.gitignore": "*.swo\n",
+	})
+
+	_, err := adapter.Generate([]string{"Vim"})
+
+	var ambiguousErr *internal.AmbiguousOptionError
+
+	require.True(t, errors.As(err, &ambiguousErr))
+	require.Equal(t, []string{"Global/Vim", "community/Vim"}, ambiguousErr.Candidates)
+}
+
+func TestGitAdapterGenerateShouldResolveAmbiguousNamesByPath(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"Global/Vim.gitignore":    "*.swp\n",
+		"community/Vim.gitignore": "*.swo\n",
+	})
+
+	contents, err := adapter.Generate([]string{"community/Vim"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### Vim ###\n*.swo\n\n", contents)
+}
+
+func TestGitAdapterUpdateShouldWriteATemplateIndex(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+
+	require.FileExists(t, adapter.IndexPath())
+
+	contents, err := os.ReadFile(adapter.IndexPath())
+	require.NoError(t, err)
+	require.Contains(t, string(contents), `"path": "C"`)
+	require.Contains(t, string(contents), `"hash": "sha256:`)
+}
+
+func TestGitAdapterListShouldUseTheIndexFromTheLastUpdate(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"C.gitignore":      "*.o\n",
+		"Python.gitignore": "__pycache__/\n",
+	})
+
+	err := os.Remove(path.Join(adapter.RepoDirectory, "Python.gitignore"))
+	require.NoError(t, err)
+
+	freshAdapter := &internal.GitAdapter{
+		Name:          adapter.Name,
+		RepoDirectory: adapter.RepoDirectory,
+		RepoURL:       adapter.RepoURL,
+	}
+
+	options, err := freshAdapter.List()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"C", "Python"}, options)
+}
+
+func TestGitAdapterUpdateShouldRefreshTheTemplateIndex(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+
+	commitTemplates(t, adapter.RepoURL, map[string]string{
+		"Python.gitignore": "__pycache__/\n",
+	})
+
+	err := adapter.Update()
+	require.NoError(t, err)
+
+	options, err := adapter.List()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"C", "Python"}, options)
+}
+
+func newBenchmarkGitAdapter(b *testing.B) *internal.GitAdapter {
+	b.Helper()
+
+	templates := map[string]string{}
+	for index := range 500 {
+		templates[path.Join("community", strconv.Itoa(index%20), "Template"+strconv.Itoa(index)+".gitignore")] = "*.tmp\n"
+	}
+
+	templates["C.gitignore"] = "*.o\n"
+	templates["Python.gitignore"] = "__pycache__/\n"
+
+	repoDir := b.TempDir()
+
+	_, err := git.PlainInit(repoDir, false)
+	require.NoError(b, err)
+
+	commitTemplates(b, repoDir, templates)
+
+	adapter := &internal.GitAdapter{
+		Name:          "github",
+		RepoDirectory: path.Join(b.TempDir(), "gitignore"),
+		RepoURL:       repoDir,
+	}
+
+	err = adapter.Update()
+	require.NoError(b, err)
+
+	return adapter
+}
+
+func benchmarkGitAdapterGenerate(b *testing.B, adapter *internal.GitAdapter) {
+	b.Helper()
+
+	for b.Loop() {
+		// A fresh adapter is used for each iteration to mimic separate
+		// invocations of the CLI.
+		freshAdapter := &internal.GitAdapter{
+			Name:          adapter.Name,
+			RepoDirectory: adapter.RepoDirectory,
+			RepoURL:       adapter.RepoURL,
+		}
+
+		_, err := freshAdapter.List()
+		require.NoError(b, err)
+
+		_, err = freshAdapter.Generate([]string{"C", "Python", "community/3/Template123"})
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkGitAdapterGenerateWithIndex(b *testing.B) {
+	adapter := newBenchmarkGitAdapter(b)
+
+	benchmarkGitAdapterGenerate(b, adapter)
+}
+
+func BenchmarkGitAdapterGenerateWithoutIndex(b *testing.B) {
+	adapter := newBenchmarkGitAdapter(b)
+
+	err := os.Remove(adapter.IndexPath())
+	require.NoError(b, err)
+
+	benchmarkGitAdapterGenerate(b, adapter)
+}
+
+func TestGitAdapterDescribeShouldReturnTheCommitTheTemplateCameFrom(t *testing.T) {
+	t.Parallel()
+
+	repoDir := newTemplateRepository(t, map[string]string{
+		"community/Golang/Hugo.gitignore": "/public/\n",
+	})
+	revision := commitTemplates(t, repoDir, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+
+	adapter := &internal.GitAdapter{
+		Name:          "github",
+		RepoDirectory: path.Join(t.TempDir(), "gitignore"),
+		RepoURL:       repoDir,
+	}
+
+	err := adapter.Update()
+	require.NoError(t, err)
+
+	template, err := adapter.Describe("hugo")
+
+	require.NoError(t, err)
+	require.Equal(t, internal.TemplateInfo{
+		Path:     "community/Golang/Hugo",
+		URL:      repoDir,
+		Revision: revision,
+	}, template)
+}
+
+func TestGitAdapterPinShouldGenerateTemplatesAsTheyWereAtTheRevision(t *testing.T) {
+	t.Parallel()
+
+	repoDir := newTemplateRepository(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+	revision := commitTemplates(t, repoDir, map[string]string{
+		"Python.gitignore": "__pycache__/\n",
+	})
+
+	adapter := &internal.GitAdapter{
+		Name:          "github",
+		RepoDirectory: path.Join(t.TempDir(), "gitignore"),
+		RepoURL:       repoDir,
+	}
+
+	err := adapter.Update()
+	require.NoError(t, err)
+
+	commitTemplates(t, repoDir, map[string]string{
+		"C.gitignore": "*.o\n*.a\n",
+	})
+
+	err = adapter.Update()
+	require.NoError(t, err)
+
+	err = adapter.Pin(revision)
+	require.NoError(t, err)
+
+	contents, err := adapter.Generate([]string{"C"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### C ###\n*.o\n\n", contents)
+
+	template, err := adapter.Describe("C")
+
+	require.NoError(t, err)
+	require.Equal(t, revision, template.Revision)
+}
+
+func TestGitAdapterPinShouldAcceptBranchNames(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+
+	err := adapter.Pin("master")
+	require.NoError(t, err)
+
+	options, err := adapter.List()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"C"}, options)
+}
+
+func TestGitAdapterPinShouldReturnAnErrorForAnUnknownRevision(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+
+	err := adapter.Pin("does-not-exist")
+
+	require.Error(t, err)
+}
+
+func TestGitAdapterPinWithAnEmptyRevisionShouldUseTheWorkingTree(t *testing.T) {
+	t.Parallel()
+
+	repoDir := newTemplateRepository(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+	revision := commitTemplates(t, repoDir, map[string]string{
+		"C.gitignore": "*.o\n*.a\n",
+	})
+
+	adapter := &internal.GitAdapter{
+		Name:          "github",
+		RepoDirectory: path.Join(t.TempDir(), "gitignore"),
+		RepoURL:       repoDir,
+	}
+
+	err := adapter.Update()
+	require.NoError(t, err)
+
+	err = adapter.Pin(revision + "~1")
+	require.NoError(t, err)
+
+	err = adapter.Pin("")
+	require.NoError(t, err)
+
+	contents, err := adapter.Generate([]string{"C"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### C ###\n*.o\n*.a\n\n", contents)
+}
+
+func TestGitAdapterChangesShouldListCommitsToTheTemplateSinceTheRevision(t *testing.T) {
+	t.Parallel()
+
+	repoDir := newTemplateRepository(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+	revision := commitTemplates(t, repoDir, map[string]string{
+		"Python.gitignore": "__pycache__/\n",
+	})
+	changedRevision := commitTemplates(t, repoDir, map[string]string{
+		"C.gitignore": "*.o\n*.a\n",
+	})
+	commitTemplates(t, repoDir, map[string]string{
+		"Python.gitignore": "__pycache__/\n*.pyc\n",
+	})
+
+	adapter := &internal.GitAdapter{
+		Name:          "github",
+		RepoDirectory: path.Join(t.TempDir(), "gitignore"),
+		RepoURL:       repoDir,
+	}
+
+	err := adapter.Update()
+	require.NoError(t, err)
+
+	changes, err := adapter.Changes("C", revision)
+
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, changedRevision, changes[0].Revision)
+	require.Equal(t, "Update templates", changes[0].Summary)
+}
+
+func TestGitAdapterChangesShouldBeEmptyWhenTheTemplateHasNotChanged(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+
+	revision, err := adapter.Describe("C")
+	require.NoError(t, err)
+
+	changes, err := adapter.Changes("C", revision.Revision)
+
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
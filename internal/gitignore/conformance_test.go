@@ -0,0 +1,111 @@
+package gitignore_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal/gitignore"
+)
+
+// conformanceCase is a single check of whether git ignores a path.
+type conformanceCase struct {
+	description string
+	gitignore   string
+	path        string
+	isDir       bool
+	ignored     bool
+}
+
+// conformanceCases were checked against `git check-ignore --no-index`
+// with the gitignore file at the root of a fresh repository.
+func conformanceCases() []conformanceCase {
+	return []conformanceCase{
+		{"literal name matches at any depth", "foo", "foo", false, true},
+		{"literal name matches at any depth", "foo", "a/b/foo", false, true},
+		{"literal name doesn't match a prefix", "foo", "foobar", false, false},
+		{"star matches within a name", "*.o", "src/main.o", false, true},
+		{"star doesn't match a slash", "src/*.o", "src/a/main.o", false, false},
+		{"star matches in an anchored pattern", "src/*.o", "src/main.o", false, true},
+		{"question mark matches a single character", "file?.txt", "file1.txt", false, true},
+		{"question mark doesn't match nothing", "file?.txt", "file.txt", false, false},
+		{"question mark doesn't match a slash", "a?b", "a/b", false, false},
+		{"leading slash anchors to the root", "/build", "build", true, true},
+		{"leading slash doesn't match deeper", "/build", "src/build", true, false},
+		{"middle slash anchors to the root", "doc/frotz", "doc/frotz", false, true},
+		{"middle slash doesn't match deeper", "doc/frotz", "a/doc/frotz", false, false},
+		{"trailing slash only matches directories", "build/", "build", false, false},
+		{"trailing slash matches directories", "build/", "build", true, true},
+		{"trailing slash matches directories at any depth", "build/", "a/build", true, true},
+		{"double star on its own matches files", "**", "foo", false, true},
+		{"double star on its own matches directories", "**", "foo", true, true},
+		{"double star on its own matches nested files", "**", "a/b", false, true},
+		{"double star directory doesn't match files", "**/", "foo", false, false},
+		{"double star directory matches directories", "**/", "foo", true, true},
+		{"double star directory matches files in directories", "**/", "a/b", false, true},
+		{"files in an ignored directory are ignored", "build/", "build/out/main.o", false, true},
+		{"anchored directory pattern", "/a/b/", "a/b", true, true},
+		{"leading double star matches at any depth", "**/foo", "a/b/foo", false, true},
+		{"leading double star matches at the root", "**/foo", "foo", false, true},
+		{"leading double star with a path", "**/foo/bar", "x/foo/bar", false, true},
+		{"trailing double star matches everything inside", "abc/**", "abc/x/y", false, true},
+		{"trailing double star doesn't match the directory itself", "abc/**", "abc", true, false},
+		{"middle double star matches no directories", "a/**/b", "a/b", false, true},
+		{"middle double star matches several directories", "a/**/b", "a/x/y/b", false, true},
+		{"consecutive stars in a name act like one", "a**b", "a/x/b", false, false},
+		{"consecutive stars in a name act like one", "a**b", "axxb", false, true},
+		{"negation re-includes a file", "*.log\n!keep.log", "keep.log", false, false},
+		{"negation only affects matching files", "*.log\n!keep.log", "other.log", false, true},
+		{"last matching pattern wins", "!keep.log\n*.log", "keep.log", false, true},
+		{"negation can't re-include a file in an ignored directory", "build/\n!build/keep", "build/keep", false, true},
+		{"negation can re-include a directory", "/*\n!/src", "src", true, false},
+		{"negation works through re-included directories", "/*\n!/src\n/src/*\n!/src/keep", "src/keep", false, false},
+		{"negation works through re-included directories", "/*\n!/src\n/src/*\n!/src/keep", "src/other", false, true},
+		{"escaped hash is a pattern", "\\#notes", "#notes", false, true},
+		{"hash starts a comment", "#notes", "#notes", false, false},
+		{"escaped exclamation mark is a pattern", "\\!important", "!important", false, true},
+		{"trailing spaces are ignored", "foo   ", "foo", false, true},
+		{"escaped trailing space is kept", "foo\\ ", "foo ", false, true},
+		{"escaped trailing space is kept", "foo\\ ", "foo", false, false},
+		{"bracket expression matches a range", "file[0-9].txt", "file5.txt", false, true},
+		{"bracket expression doesn't match outside the range", "file[0-9].txt", "fileA.txt", false, false},
+		{"negated bracket expression", "file[!0-9].txt", "fileA.txt", false, true},
+		{"negated bracket expression with a caret", "file[^0-9].txt", "file5.txt", false, false},
+		{"closing bracket first is literal", "[]a]", "]", false, true},
+		{"named character class", "[[:digit:]]*", "1file", false, true},
+		{"named character class", "[[:upper:]]*", "readme", false, false},
+		{"unterminated bracket never matches", "[abc", "[abc", false, false},
+		{"escaped star is literal", "a\\*b", "a*b", false, true},
+		{"escaped star is literal", "a\\*b", "axb", false, false},
+		{"matching is case sensitive", "README", "readme", false, false},
+		{"pattern without a slash matches directories", "node_modules", "web/node_modules/left-pad/index.js", false, true},
+		{"anchored pattern with a star segment", "/*/generated", "api/generated", true, true},
+		{"anchored pattern with a star segment", "/*/generated", "a/api/generated", true, false},
+		{"windows line endings are ignored", "*.o\r\nbuild/\r\n", "main.o", false, true},
+		{"windows line endings are ignored", "*.o\r\nbuild/\r\n", "build/x", false, true},
+		{"byte order mark is skipped", "\uFEFFmain.o\n", "main.o", false, true},
+		{"segment of more than two stars", "a/***/b", "a/x/y/b", false, true},
+		{"segment of more than two stars", "a/***/b", "a/b", false, true},
+		{"segment of only stars", "***", "foo", false, true},
+		{"escaped slash", "a\\/b", "a/b", false, true},
+		{"escaped slash", "a\\/b", "x/a/b", false, false},
+	}
+}
+
+func TestMatcherShouldMatchPathsTheSameWayAsGit(t *testing.T) {
+	t.Parallel()
+
+	for _, testCase := range conformanceCases() {
+		name := fmt.Sprintf("%s (%q against %s)", testCase.description, testCase.gitignore, testCase.path)
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			matcher := gitignore.NewMatcher()
+			matcher.AddPatterns(".gitignore", "", testCase.gitignore)
+
+			require.Equal(t, testCase.ignored, matcher.Ignored(testCase.path, testCase.isDir))
+		})
+	}
+}
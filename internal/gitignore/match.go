@@ -0,0 +1,157 @@
+package gitignore
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Match reports whether the pattern matches a path. The path uses
+// slashes and is relative to the directory of the gitignore file the
+// pattern came from. Negated patterns match the same paths, it's up to
+// the caller to re-include them.
+func (pattern Pattern) Match(filePath string, isDir bool) bool {
+	if pattern.Malformed || (pattern.DirectoryOnly && !isDir) {
+		return false
+	}
+
+	if !pattern.Anchored {
+		// A "**" on its own matches every name like a "*" would.
+		if pattern.Segments[0].DoubleStar {
+			return true
+		}
+
+		return matchGlob(pattern.Segments[0].Tokens, []rune(path.Base(filePath)))
+	}
+
+	return matchSegments(pattern.Segments, strings.Split(filePath, "/"))
+}
+
+func matchSegments(segments []Segment, parts []string) bool {
+	if len(segments) == 0 {
+		return len(parts) == 0
+	}
+
+	segment := segments[0]
+
+	if !segment.DoubleStar {
+		return len(parts) > 0 &&
+			matchGlob(segment.Tokens, []rune(parts[0])) &&
+			matchSegments(segments[1:], parts[1:])
+	}
+
+	// A trailing "**" matches everything inside of a directory but
+	// not the directory itself.
+	if len(segments) == 1 {
+		return len(parts) > 0
+	}
+
+	for skipped := 0; skipped <= len(parts); skipped++ {
+		if matchSegments(segments[1:], parts[skipped:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchGlob(tokens []Token, text []rune) bool {
+	if len(tokens) == 0 {
+		return len(text) == 0
+	}
+
+	token := tokens[0]
+
+	switch token.Kind {
+	case TokenLiteral:
+		literal := []rune(token.Literal)
+
+		return len(text) >= len(literal) &&
+			slices.Equal(text[:len(literal)], literal) &&
+			matchGlob(tokens[1:], text[len(literal):])
+
+	case TokenStar:
+		for skipped := 0; skipped <= len(text); skipped++ {
+			if matchGlob(tokens[1:], text[skipped:]) {
+				return true
+			}
+		}
+
+		return false
+
+	case TokenQuestion:
+		return len(text) > 0 && matchGlob(tokens[1:], text[1:])
+
+	case TokenClass:
+		return len(text) > 0 && token.Class.matches(text[0]) && matchGlob(tokens[1:], text[1:])
+	}
+
+	return false
+}
+
+func (class *CharClass) matches(char rune) bool {
+	matched := false
+
+	for _, charRange := range class.Ranges {
+		if charRange.Low <= char && char <= charRange.High {
+			matched = true
+		}
+	}
+
+	for _, name := range class.Named {
+		if namedCharClassMatches(name, char) {
+			matched = true
+		}
+	}
+
+	return matched != class.Negated
+}
+
+// knownCharClass reports whether the name is one of the POSIX
+// character classes git supports.
+func knownCharClass(name string) bool {
+	switch name {
+	case "alnum", "alpha", "blank", "cntrl", "digit", "graph",
+		"lower", "print", "punct", "space", "upper", "xdigit":
+		return true
+	default:
+		return false
+	}
+}
+
+//nolint:cyclop // One case per character class
+func namedCharClassMatches(name string, char rune) bool {
+	if char > unicode.MaxASCII {
+		return false
+	}
+
+	switch name {
+	case "alnum":
+		return unicode.IsLetter(char) || unicode.IsDigit(char)
+	case "alpha":
+		return unicode.IsLetter(char)
+	case "blank":
+		return char == ' ' || char == '\t'
+	case "cntrl":
+		return unicode.IsControl(char)
+	case "digit":
+		return unicode.IsDigit(char)
+	case "graph":
+		return unicode.IsGraphic(char) && char != ' '
+	case "lower":
+		return unicode.IsLower(char)
+	case "print":
+		return unicode.IsPrint(char)
+	case "punct":
+		return unicode.IsPunct(char) || unicode.IsSymbol(char)
+	case "space":
+		return unicode.IsSpace(char)
+	case "upper":
+		return unicode.IsUpper(char)
+	case "xdigit":
+		return strings.ContainsRune("0123456789abcdefABCDEF", char)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,139 @@
+package gitignore
+
+import (
+	"slices"
+	"strings"
+)
+
+// Matcher matches paths against a set of gitignore files the same way
+// git does. Paths use slashes and are relative to the root of the
+// repository.
+type Matcher struct {
+	files []*File
+}
+
+// File is a parsed gitignore file.
+type File struct {
+	// Source describes where the file came from, usually its path.
+	Source string
+
+	// Directory is the directory the file's patterns are relative to,
+	// e.g. "" for the root of the repository or "docs" for
+	// docs/.gitignore.
+	Directory string
+
+	Patterns []Pattern
+}
+
+// MatchResult is the pattern that decided whether a path is ignored.
+type MatchResult struct {
+	File    *File
+	Pattern Pattern
+
+	// Path is the path the pattern matched. It's a parent directory of
+	// the path that was checked if that directory is ignored.
+	Path string
+}
+
+// Ignored reports whether the pattern ignores the path rather than
+// re-including it.
+func (result *MatchResult) Ignored() bool {
+	return !result.Pattern.Negated
+}
+
+// NewMatcher creates an empty matcher.
+func NewMatcher() *Matcher {
+	return &Matcher{files: []*File{}}
+}
+
+// Add adds a gitignore file to the matcher. Files in deeper directories
+// take precedence over files in the directories above them and files
+// in the same directory take precedence over the ones added before
+// them, so files that apply to the whole repository such as
+// info/exclude should be added before the root .gitignore file.
+func (matcher *Matcher) Add(file *File) {
+	matcher.files = append(matcher.files, file)
+
+	slices.SortStableFunc(matcher.files, func(a, b *File) int {
+		return directoryDepth(a.Directory) - directoryDepth(b.Directory)
+	})
+}
+
+// AddPatterns parses the contents of a gitignore file and adds it to
+// the matcher.
+func (matcher *Matcher) AddPatterns(source string, directory string, contents string) *File {
+	file := &File{
+		Source:    source,
+		Directory: strings.Trim(directory, "/"),
+		Patterns:  Parse(contents),
+	}
+
+	matcher.Add(file)
+
+	return file
+}
+
+// Match returns the pattern that decides whether the path is ignored
+// or nil if no pattern matches it. Once a directory is ignored
+// everything inside of it is ignored too, even if a later pattern
+// would re-include it.
+func (matcher *Matcher) Match(filePath string, isDir bool) *MatchResult {
+	filePath = strings.Trim(filePath, "/")
+	parts := strings.Split(filePath, "/")
+
+	for depth := 1; depth < len(parts); depth++ {
+		result := matcher.matchPath(strings.Join(parts[:depth], "/"), true)
+		if result != nil && result.Ignored() {
+			return result
+		}
+	}
+
+	return matcher.matchPath(filePath, isDir)
+}
+
+// Ignored reports whether the path is ignored.
+func (matcher *Matcher) Ignored(filePath string, isDir bool) bool {
+	result := matcher.Match(filePath, isDir)
+
+	return result != nil && result.Ignored()
+}
+
+// matchPath finds the last pattern that matches the path without
+// looking at its parent directories.
+func (matcher *Matcher) matchPath(filePath string, isDir bool) *MatchResult {
+	for fileIndex := len(matcher.files) - 1; fileIndex >= 0; fileIndex-- {
+		file := matcher.files[fileIndex]
+
+		relativePath, ok := relativeTo(file.Directory, filePath)
+		if !ok {
+			continue
+		}
+
+		for patternIndex := len(file.Patterns) - 1; patternIndex >= 0; patternIndex-- {
+			pattern := file.Patterns[patternIndex]
+			if pattern.Match(relativePath, isDir) {
+				return &MatchResult{File: file, Pattern: pattern, Path: filePath}
+			}
+		}
+	}
+
+	return nil
+}
+
+// relativeTo returns the path relative to the given directory if it's
+// inside of it.
+func relativeTo(directory string, filePath string) (string, bool) {
+	if directory == "" {
+		return filePath, true
+	}
+
+	return strings.CutPrefix(filePath, directory+"/")
+}
+
+func directoryDepth(directory string) int {
+	if directory == "" {
+		return 0
+	}
+
+	return strings.Count(directory, "/") + 1
+}
@@ -0,0 +1,67 @@
+package gitignore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal/gitignore"
+)
+
+func TestMatcherShouldPreferPatternsFromDeeperFiles(t *testing.T) {
+	t.Parallel()
+
+	matcher := gitignore.NewMatcher()
+	matcher.AddPatterns("docs/.gitignore", "docs", "!important.log\n")
+	matcher.AddPatterns(".gitignore", "", "*.log\n")
+
+	require.False(t, matcher.Ignored("docs/important.log", false))
+	require.True(t, matcher.Ignored("important.log", false))
+	require.True(t, matcher.Ignored("docs/other.log", false))
+}
+
+func TestMatcherShouldOnlyApplyFilesToTheirOwnDirectory(t *testing.T) {
+	t.Parallel()
+
+	matcher := gitignore.NewMatcher()
+	matcher.AddPatterns("docs/.gitignore", "docs", "/build\n")
+
+	require.True(t, matcher.Ignored("docs/build", true))
+	require.False(t, matcher.Ignored("build", true))
+	require.False(t, matcher.Ignored("docsbuild", true))
+}
+
+func TestMatcherShouldPreferFilesAddedLaterInTheSameDirectory(t *testing.T) {
+	t.Parallel()
+
+	matcher := gitignore.NewMatcher()
+	matcher.AddPatterns(".git/info/exclude", "", "*.local\n")
+	matcher.AddPatterns(".gitignore", "", "!settings.local\n")
+
+	require.False(t, matcher.Ignored("settings.local", false))
+	require.True(t, matcher.Ignored("other.local", false))
+}
+
+func TestMatcherMatchShouldReportThePatternThatDecided(t *testing.T) {
+	t.Parallel()
+
+	matcher := gitignore.NewMatcher()
+	file := matcher.AddPatterns(".gitignore", "", "# Build output\nbuild/\n*.o\n")
+
+	result := matcher.Match("build/cmd/main.o", false)
+
+	require.NotNil(t, result)
+	require.True(t, result.Ignored())
+	require.Same(t, file, result.File)
+	require.Equal(t, 2, result.Pattern.Line)
+	require.Equal(t, "build", result.Path)
+}
+
+func TestMatcherMatchShouldReturnNilWhenNothingMatches(t *testing.T) {
+	t.Parallel()
+
+	matcher := gitignore.NewMatcher()
+	matcher.AddPatterns(".gitignore", "", "*.o\n")
+
+	require.Nil(t, matcher.Match("main.go", false))
+}
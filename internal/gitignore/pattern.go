@@ -0,0 +1,366 @@
+// Package gitignore parses the patterns in gitignore files and matches
+// paths against them the same way git does.
+package gitignore
+
+import (
+	"strings"
+)
+
+// TokenKind is the kind of a single token in a glob.
+type TokenKind int
+
+const (
+	// TokenLiteral matches its text exactly.
+	TokenLiteral TokenKind = iota
+
+	// TokenStar matches any number of characters other than a slash.
+	TokenStar
+
+	// TokenQuestion matches a single character other than a slash.
+	TokenQuestion
+
+	// TokenClass matches a single character in a bracket expression,
+	// e.g. [a-z].
+	TokenClass
+)
+
+// Token is a single part of a glob.
+type Token struct {
+	Kind TokenKind
+
+	// Literal is the text a TokenLiteral matches with any escapes
+	// removed.
+	Literal string
+
+	// Class is the bracket expression a TokenClass matches.
+	Class *CharClass
+}
+
+// CharClass is a bracket expression such as [a-z] or [!0-9].
+type CharClass struct {
+	// Negated is true if the class matches any character except the
+	// ones listed, e.g. [!abc] or [^abc].
+	Negated bool
+
+	Ranges []CharRange
+
+	// Named are the character classes such as "alpha" or "digit"
+	// from [:alpha:] or [:digit:].
+	Named []string
+}
+
+// CharRange is a range of characters in a bracket expression. Single
+// characters are a range with the same low and high character.
+type CharRange struct {
+	Low  rune
+	High rune
+}
+
+// Segment is the part of a pattern between two slashes.
+type Segment struct {
+	// DoubleStar is true for a "**" segment, or any other segment of
+	// just stars, which matches any number of directories.
+	DoubleStar bool
+
+	// Tokens make up the glob the segment matches. It is empty for a
+	// "**" segment.
+	Tokens []Token
+}
+
+// Pattern is a single pattern from a gitignore file.
+type Pattern struct {
+	// Line is the line number the pattern was on, starting from 1.
+	Line int
+
+	// Text is the line the pattern was parsed from.
+	Text string
+
+	// Negated is true for patterns starting with "!" which re-include
+	// anything an earlier pattern excluded.
+	Negated bool
+
+	// Anchored is true if the pattern has a slash at the beginning or
+	// in the middle. Anchored patterns match paths relative to the
+	// directory of the gitignore file while everything else matches
+	// the name of a file or directory at any depth.
+	Anchored bool
+
+	// DirectoryOnly is true if the pattern ends with a slash so it
+	// only matches directories.
+	DirectoryOnly bool
+
+	Segments []Segment
+
+	// Malformed is true if the pattern can't be parsed, e.g. it has an
+	// unterminated bracket expression. Git never matches these
+	// patterns.
+	Malformed bool
+}
+
+// Parse parses the contents of a gitignore file. Blank lines and
+// comments are skipped.
+func Parse(contents string) []Pattern {
+	patterns := []Pattern{}
+
+	// Git skips a byte order mark at the start of the file.
+	contents = strings.TrimPrefix(contents, "\uFEFF")
+
+	for index, line := range strings.Split(contents, "\n") {
+		pattern, ok := ParsePattern(line)
+		if !ok {
+			continue
+		}
+
+		pattern.Line = index + 1
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns
+}
+
+// ParsePattern parses a single line of a gitignore file. It returns
+// false if the line is blank or a comment.
+func ParsePattern(line string) (Pattern, bool) {
+	// Files with Windows line endings still match the same paths.
+	line = strings.TrimSuffix(line, "\r")
+
+	pattern := Pattern{
+		Line:          0,
+		Text:          line,
+		Negated:       false,
+		Anchored:      false,
+		DirectoryOnly: false,
+		Segments:      []Segment{},
+		Malformed:     false,
+	}
+
+	text := trimTrailingSpaces(line)
+	if text == "" || strings.HasPrefix(text, "#") {
+		return pattern, false
+	}
+
+	if rest, ok := strings.CutPrefix(text, "!"); ok {
+		pattern.Negated = true
+		text = rest
+	}
+
+	if rest, ok := strings.CutSuffix(text, "/"); ok {
+		pattern.DirectoryOnly = true
+		text = rest
+	}
+
+	if strings.Contains(text, "/") {
+		pattern.Anchored = true
+		text = strings.TrimPrefix(text, "/")
+	}
+
+	if text == "" {
+		return pattern, false
+	}
+
+	for _, segment := range splitSegments(text) {
+		if len(segment) >= 2 && strings.Trim(segment, "*") == "" {
+			pattern.Segments = append(pattern.Segments, Segment{DoubleStar: true, Tokens: []Token{}})
+
+			continue
+		}
+
+		tokens, ok := parseGlob(segment)
+		if !ok {
+			pattern.Malformed = true
+		}
+
+		pattern.Segments = append(pattern.Segments, Segment{DoubleStar: false, Tokens: tokens})
+	}
+
+	return pattern, true
+}
+
+// splitSegments splits a pattern at its slashes. An escaped slash
+// still matches the slash between two directories so it splits the
+// pattern too, without the backslash.
+func splitSegments(text string) []string {
+	segments := strings.Split(text, "/")
+
+	for index, segment := range segments[:len(segments)-1] {
+		backslashes := len(segment) - len(strings.TrimRight(segment, "\\"))
+		if backslashes%2 == 1 {
+			segments[index] = segment[:len(segment)-1]
+		}
+	}
+
+	return segments
+}
+
+// trimTrailingSpaces removes the spaces at the end of a line unless
+// they are escaped with a backslash.
+func trimTrailingSpaces(line string) string {
+	end := len(line)
+	for end > 0 && line[end-1] == ' ' {
+		end--
+	}
+
+	if end == len(line) {
+		return line
+	}
+
+	// Count the backslashes before the spaces to see if the first
+	// space is escaped.
+	backslashes := 0
+	for index := end - 1; index >= 0 && line[index] == '\\'; index-- {
+		backslashes++
+	}
+
+	if backslashes%2 == 1 {
+		end++
+	}
+
+	return line[:end]
+}
+
+// parseGlob parses the glob for a single segment of a pattern. It
+// returns false if the glob is malformed.
+func parseGlob(glob string) ([]Token, bool) {
+	tokens := []Token{}
+	runes := []rune(glob)
+
+	var literal strings.Builder
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			tokens = append(tokens, Token{Kind: TokenLiteral, Literal: literal.String(), Class: nil})
+			literal.Reset()
+		}
+	}
+
+	for position := 0; position < len(runes); position++ {
+		switch runes[position] {
+		case '\\':
+			// A trailing backslash doesn't escape anything so git
+			// never matches it.
+			if position+1 >= len(runes) {
+				return tokens, false
+			}
+
+			position++
+			literal.WriteRune(runes[position])
+
+		case '*':
+			flushLiteral()
+
+			// Consecutive stars that aren't a segment of their own
+			// act like a single star.
+			for position+1 < len(runes) && runes[position+1] == '*' {
+				position++
+			}
+
+			tokens = append(tokens, Token{Kind: TokenStar, Literal: "", Class: nil})
+
+		case '?':
+			flushLiteral()
+			tokens = append(tokens, Token{Kind: TokenQuestion, Literal: "", Class: nil})
+
+		case '[':
+			flushLiteral()
+
+			class, end, ok := parseCharClass(runes, position)
+			if !ok {
+				return tokens, false
+			}
+
+			tokens = append(tokens, Token{Kind: TokenClass, Literal: "", Class: class})
+			position = end
+
+		default:
+			literal.WriteRune(runes[position])
+		}
+	}
+
+	flushLiteral()
+
+	return tokens, true
+}
+
+// parseCharClass parses the bracket expression starting at the given
+// position. It returns the position of the closing bracket.
+func parseCharClass(runes []rune, start int) (*CharClass, int, bool) {
+	class := &CharClass{Negated: false, Ranges: []CharRange{}, Named: []string{}}
+	position := start + 1
+
+	if position < len(runes) && (runes[position] == '!' || runes[position] == '^') {
+		class.Negated = true
+		position++
+	}
+
+	first := true
+
+	for ; position < len(runes); position++ {
+		char := runes[position]
+
+		// A closing bracket right at the start is part of the class.
+		if char == ']' && !first {
+			return class, position, true
+		}
+
+		first = false
+
+		if char == '[' && position+1 < len(runes) && runes[position+1] == ':' {
+			name, end, ok := parseNamedClass(runes, position)
+			if ok {
+				if !knownCharClass(name) {
+					return nil, 0, false
+				}
+
+				class.Named = append(class.Named, name)
+				position = end
+
+				continue
+			}
+		}
+
+		if char == '\\' {
+			position++
+			if position >= len(runes) {
+				return nil, 0, false
+			}
+
+			char = runes[position]
+		}
+
+		low, high := char, char
+
+		if position+2 < len(runes) && runes[position+1] == '-' && runes[position+2] != ']' {
+			position += 2
+			high = runes[position]
+
+			if high == '\\' {
+				position++
+				if position >= len(runes) {
+					return nil, 0, false
+				}
+
+				high = runes[position]
+			}
+		}
+
+		class.Ranges = append(class.Ranges, CharRange{Low: low, High: high})
+	}
+
+	return nil, 0, false
+}
+
+// parseNamedClass parses a character class name like [:alpha:] inside
+// of a bracket expression.
+func parseNamedClass(runes []rune, start int) (string, int, bool) {
+	for end := start + 2; end+1 < len(runes); end++ {
+		if runes[end] == ':' && runes[end+1] == ']' {
+			return string(runes[start+2 : end]), end + 1, true
+		}
+
+		if runes[end] == ']' {
+			break
+		}
+	}
+
+	return "", 0, false
+}
@@ -0,0 +1,84 @@
+package gitignore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal/gitignore"
+)
+
+func TestParseShouldSkipBlankLinesAndComments(t *testing.T) {
+	t.Parallel()
+
+	patterns := gitignore.Parse("# Build output\n\n/build/\n   \n*.o\n")
+
+	require.Len(t, patterns, 2)
+	require.Equal(t, 3, patterns[0].Line)
+	require.Equal(t, "/build/", patterns[0].Text)
+	require.Equal(t, 5, patterns[1].Line)
+}
+
+func TestParsePatternShouldRecordNegationAnchoringAndDirectories(t *testing.T) {
+	t.Parallel()
+
+	pattern, ok := gitignore.ParsePattern("!/build/")
+
+	require.True(t, ok)
+	require.True(t, pattern.Negated)
+	require.True(t, pattern.Anchored)
+	require.True(t, pattern.DirectoryOnly)
+	require.Equal(t, []gitignore.Segment{
+		{DoubleStar: false, Tokens: []gitignore.Token{{Kind: gitignore.TokenLiteral, Literal: "build", Class: nil}}},
+	}, pattern.Segments)
+}
+
+func TestParsePatternShouldNotAnchorPatternsWithOnlyATrailingSlash(t *testing.T) {
+	t.Parallel()
+
+	pattern, ok := gitignore.ParsePattern("node_modules/")
+
+	require.True(t, ok)
+	require.False(t, pattern.Anchored)
+	require.True(t, pattern.DirectoryOnly)
+}
+
+func TestParsePatternShouldParseGlobs(t *testing.T) {
+	t.Parallel()
+
+	pattern, ok := gitignore.ParsePattern(`**/lib/*.py[!co]\?`)
+
+	require.True(t, ok)
+	require.Equal(t, []gitignore.Segment{
+		{DoubleStar: true, Tokens: []gitignore.Token{}},
+		{DoubleStar: false, Tokens: []gitignore.Token{{Kind: gitignore.TokenLiteral, Literal: "lib", Class: nil}}},
+		{DoubleStar: false, Tokens: []gitignore.Token{
+			{Kind: gitignore.TokenStar, Literal: "", Class: nil},
+			{Kind: gitignore.TokenLiteral, Literal: ".py", Class: nil},
+			{Kind: gitignore.TokenClass, Literal: "", Class: &gitignore.CharClass{
+				Negated: true,
+				Ranges:  []gitignore.CharRange{{Low: 'c', High: 'c'}, {Low: 'o', High: 'o'}},
+				Named:   []string{},
+			}},
+			{Kind: gitignore.TokenLiteral, Literal: "?", Class: nil},
+		}},
+	}, pattern.Segments)
+}
+
+func TestParsePatternShouldKeepEscapedTrailingSpaces(t *testing.T) {
+	t.Parallel()
+
+	pattern, ok := gitignore.ParsePattern(`name\   `)
+
+	require.True(t, ok)
+	require.Equal(t, []gitignore.Token{{Kind: gitignore.TokenLiteral, Literal: "name ", Class: nil}}, pattern.Segments[0].Tokens)
+}
+
+func TestParsePatternShouldMarkUnterminatedBracketsAsMalformed(t *testing.T) {
+	t.Parallel()
+
+	pattern, ok := gitignore.ParsePattern("[a-z")
+
+	require.True(t, ok)
+	require.True(t, pattern.Malformed)
+}
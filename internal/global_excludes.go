@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gitconfig "github.com/go-git/go-git/v5/plumbing/format/config"
+)
+
+// GlobalExcludesFilePath returns the path of the user's global
+// excludes file. This is the file set by core.excludesFile in their
+// git config or git's default of $XDG_CONFIG_HOME/git/ignore.
+func GlobalExcludesFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to get user home directory: %w", err)
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	excludesFile := ""
+
+	for _, configPath := range gitConfigPaths(home, configHome) {
+		value, err := readGitConfigValue(configPath, "core", "excludesFile")
+		if err != nil {
+			return "", err
+		}
+
+		if value != "" {
+			excludesFile = value
+		}
+	}
+
+	if excludesFile == "" {
+		return filepath.Join(configHome, "git", "ignore"), nil
+	}
+
+	if rest, ok := strings.CutPrefix(excludesFile, "~/"); ok {
+		return filepath.Join(home, rest), nil
+	}
+
+	return excludesFile, nil
+}
+
+// gitConfigPaths returns the git config files that apply to every
+// repository in the order git reads them, i.e. the last one wins.
+func gitConfigPaths(home string, configHome string) []string {
+	paths := []string{}
+
+	if os.Getenv("GIT_CONFIG_NOSYSTEM") == "" {
+		paths = append(paths, "/etc/gitconfig")
+	}
+
+	if globalConfig := os.Getenv("GIT_CONFIG_GLOBAL"); globalConfig != "" {
+		return append(paths, globalConfig)
+	}
+
+	return append(paths, filepath.Join(configHome, "git", "config"), filepath.Join(home, ".gitconfig"))
+}
+
+// readGitConfigValue reads a single value from a git config file. It
+// returns an empty string if the file or the value doesn't exist.
+func readGitConfigValue(configPath string, section string, key string) (string, error) {
+	file, err := os.Open(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to read git config %s: %w", configPath, err)
+	}
+	defer file.Close()
+
+	config := gitconfig.New()
+
+	err = gitconfig.NewDecoder(file).Decode(config)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse git config %s: %w", configPath, err)
+	}
+
+	if !config.HasSection(section) {
+		return "", nil
+	}
+
+	return config.Section(section).Option(key), nil
+}
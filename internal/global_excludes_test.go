@@ -0,0 +1,64 @@
+package internal_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+// setGitEnvironment points git's config lookups at a fresh home
+// directory so the tests don't depend on the machine's config.
+func setGitEnvironment(t *testing.T) string {
+	t.Helper()
+
+	home := t.TempDir()
+
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("GIT_CONFIG_GLOBAL", "")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	return home
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestGlobalExcludesFilePathShouldDefaultToTheXDGIgnoreFile(t *testing.T) {
+	home := setGitEnvironment(t)
+
+	excludesPath, err := internal.GlobalExcludesFilePath()
+
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(home, ".config", "git", "ignore"), excludesPath)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestGlobalExcludesFilePathShouldUseTheConfiguredExcludesFile(t *testing.T) {
+	home := setGitEnvironment(t)
+	writeTemplates(t, home, map[string]string{
+		".config/git/config": "[core]\n\texcludesFile = /etc/xdg-ignore\n",
+		".gitconfig":         "[user]\n\tname = Test\n[core]\n\texcludesfile = ~/.gitignore_global\n",
+	})
+
+	excludesPath, err := internal.GlobalExcludesFilePath()
+
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(home, ".gitignore_global"), excludesPath)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestGlobalExcludesFilePathShouldRespectGitConfigGlobal(t *testing.T) {
+	home := setGitEnvironment(t)
+	writeTemplates(t, home, map[string]string{
+		"custom.gitconfig": "[core]\n\texcludesFile = /srv/ignore\n",
+		".gitconfig":       "[core]\n\texcludesFile = /etc/ignore\n",
+	})
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(home, "custom.gitconfig"))
+
+	excludesPath, err := internal.GlobalExcludesFilePath()
+
+	require.NoError(t, err)
+	require.Equal(t, "/srv/ignore", excludesPath)
+}
@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultHTTPURL is the default gitignore.io compatible API to use
+// for gitignore files.
+const DefaultHTTPURL string = "https://www.toptal.com/developers/gitignore"
+
+// DefaultHTTPSourceName is the default name for the HTTP source.
+const DefaultHTTPSourceName string = "gitignoreio"
+
+const defaultHTTPTimeout = 30 * time.Second
+
+// HTTPAdapter is an adapter for pulling gitignore data from a
+// gitignore.io compatible HTTP API.
+type HTTPAdapter struct {
+	Name       string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewHTTPAdapter creates a new adapter for working with the
+// gitignore.io API.
+func NewHTTPAdapter() *HTTPAdapter {
+	return &HTTPAdapter{
+		Name:    DefaultHTTPSourceName,
+		BaseURL: DefaultHTTPURL,
+		//nolint:exhaustruct // Only the timeout needs to be set
+		HTTPClient: &http.Client{
+			Timeout: defaultHTTPTimeout,
+		},
+	}
+}
+
+// SourceName returns the name of the source this adapter pulls
+// templates from.
+func (adapter *HTTPAdapter) SourceName() string {
+	return adapter.Name
+}
+
+// List returns the list of options that can be used to generate a
+// gitignore file.
+func (adapter *HTTPAdapter) List() ([]string, error) {
+	body, err := adapter.get("list")
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve option list: %w", err)
+	}
+
+	options := []string{}
+
+	for _, line := range strings.Split(body, "\n") {
+		for _, option := range strings.Split(line, ",") {
+			option = strings.TrimSpace(option)
+			if option == "" {
+				continue
+			}
+
+			options = append(options, option)
+		}
+	}
+
+	return options, nil
+}
+
+// Generate creates a gitignore file with the given options.
+func (adapter *HTTPAdapter) Generate(options []string) (string, error) {
+	if len(options) == 0 {
+		return "", errors.New("must give at least one option")
+	}
+
+	escapedOptions := make([]string, 0, len(options))
+	for _, option := range options {
+		escapedOptions = append(escapedOptions, url.PathEscape(strings.ToLower(option)))
+	}
+
+	body, err := adapter.get(strings.Join(escapedOptions, ","))
+	if err != nil {
+		return "", fmt.Errorf("unable to generate gitignore data: %w", err)
+	}
+
+	return body, nil
+}
+
+// Describe returns where the template for the given option came from.
+func (adapter *HTTPAdapter) Describe(option string) (TemplateInfo, error) {
+	return TemplateInfo{
+		Path:     strings.ToLower(option),
+		URL:      strings.TrimSuffix(adapter.BaseURL, "/"),
+		Revision: "",
+	}, nil
+}
+
+// Update updates this plugin's local data. The HTTP adapter has no
+// local data so this does nothing.
+func (adapter *HTTPAdapter) Update() error {
+	return nil
+}
+
+func (adapter *HTTPAdapter) get(endpoint string) (string, error) {
+	requestURL := strings.TrimSuffix(adapter.BaseURL, "/") + "/api/" + endpoint
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, requestURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to create request for %s: %w", requestURL, err)
+	}
+
+	httpClient := adapter.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("unable to request %s: %w", requestURL, err)
+	}
+
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to read response from %s: %w", requestURL, err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d from %s", response.StatusCode, requestURL)
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,138 @@
+package internal_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func newGitignoreIOServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	templates := map[string]string{
+		"c":      "### C ###\n*.o\n",
+		"c++":    "### C++ ###\n*.obj\n",
+		"python": "### Python ###\n__pycache__/\n",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		endpoint := strings.TrimPrefix(request.URL.Path, "/api/")
+
+		if endpoint == "list" {
+			_, _ = writer.Write([]byte("c,c++\npython\n"))
+
+			return
+		}
+
+		var builder strings.Builder
+		for _, option := range strings.Split(endpoint, ",") {
+			content, ok := templates[option]
+			if !ok {
+				writer.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			builder.WriteString(content)
+		}
+
+		_, _ = writer.Write([]byte(builder.String()))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestHTTPAdapter(server *httptest.Server) *internal.HTTPAdapter {
+	return &internal.HTTPAdapter{
+		Name:       "gitignoreio",
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+}
+
+func TestHTTPAdapterListShouldRetrieveAListOfOptions(t *testing.T) {
+	t.Parallel()
+
+	adapter := newTestHTTPAdapter(newGitignoreIOServer(t))
+
+	options, err := adapter.List()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "c++", "python"}, options)
+}
+
+func TestHTTPAdapterListShouldReturnAnErrorWhenTheServerFails(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	adapter := newTestHTTPAdapter(server)
+
+	_, err := adapter.List()
+
+	require.Error(t, err)
+}
+
+func TestHTTPAdapterGenerateShouldReturnAnErrorWhenNoOptionsAreGiven(t *testing.T) {
+	t.Parallel()
+
+	adapter := newTestHTTPAdapter(newGitignoreIOServer(t))
+
+	_, err := adapter.Generate([]string{})
+
+	require.Error(t, err)
+}
+
+func TestHTTPAdapterGenerateShouldReturnAnErrorWhenGivenAnInvalidOption(t *testing.T) {
+	t.Parallel()
+
+	adapter := newTestHTTPAdapter(newGitignoreIOServer(t))
+
+	_, err := adapter.Generate([]string{"iaminvalid"})
+
+	require.Error(t, err)
+}
+
+func TestHTTPAdapterGenerateShouldCreateAGitignoreFileWhenGivenMultipleOptions(t *testing.T) {
+	t.Parallel()
+
+	adapter := newTestHTTPAdapter(newGitignoreIOServer(t))
+
+	contents, err := adapter.Generate([]string{"c", "c++", "python"})
+
+	require.NoError(t, err)
+	require.Contains(t, contents, "### C ###")
+	require.Contains(t, contents, "### C++ ###")
+	require.Contains(t, contents, "__pycache__/")
+}
+
+func TestHTTPAdapterUpdateShouldDoNothing(t *testing.T) {
+	t.Parallel()
+
+	adapter := newTestHTTPAdapter(newGitignoreIOServer(t))
+
+	err := adapter.Update()
+
+	require.NoError(t, err)
+}
+
+func TestHTTPAdapterGenerateShouldMatchOptionsWithoutRegardToCase(t *testing.T) {
+	t.Parallel()
+
+	adapter := newTestHTTPAdapter(newGitignoreIOServer(t))
+
+	contents, err := adapter.Generate([]string{"Python"})
+
+	require.NoError(t, err)
+	require.Contains(t, contents, "__pycache__/")
+}
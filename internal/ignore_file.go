@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// IgnoreFileName is the name of the file git reads ignore rules from.
+const IgnoreFileName string = ".gitignore"
+
+// ErrIgnoreFileExists is returned when writing an ignore file would
+// overwrite an existing one.
+var ErrIgnoreFileExists = errors.New("ignore file already exists")
+
+// DefaultIgnoreFilePath returns the path of the .gitignore file at the
+// root of the repository containing the given directory. If the
+// directory isn't in a repository the .gitignore file in the
+// directory itself is used instead.
+func DefaultIgnoreFilePath(directory string) (string, error) {
+	return repositoryFilePath(directory, IgnoreFileName)
+}
+
+// repositoryFilePath returns the path of the named file at the root of
+// the repository containing the given directory, or in the directory
+// itself if it isn't in a repository.
+func repositoryFilePath(directory string, fileName string) (string, error) {
+	repoRoot, err := FindRepositoryRoot(directory)
+	if errors.Is(err, ErrNotARepository) {
+		repoRoot, err = filepath.Abs(directory)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(repoRoot, fileName), nil
+}
+
+// ReadIgnoreFile returns the contents of the given ignore file or an
+// empty string if it doesn't exist yet.
+func ReadIgnoreFile(filePath string) (string, error) {
+	contents, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", filePath, err)
+	}
+
+	return string(contents), nil
+}
+
+// WriteIgnoreFile writes the contents to the given file. Existing
+// files are only overwritten if force is set.
+func WriteIgnoreFile(filePath string, contents string, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+
+	//nolint:gosec // Ignore files are meant to be readable by everyone
+	file, err := os.OpenFile(filePath, flags, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%w: %s", ErrIgnoreFileExists, filePath)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", filePath, err)
+	}
+
+	_, err = file.WriteString(contents)
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("unable to write %s: %w", filePath, err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("unable to write %s: %w", filePath, err)
+	}
+
+	return nil
+}
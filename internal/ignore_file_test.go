@@ -0,0 +1,85 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestDefaultIgnoreFilePathShouldUseTheRepositoryRoot(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	subDir := filepath.Join(repoDir, "src")
+
+	err := os.MkdirAll(filepath.Join(repoDir, ".git"), 0o750)
+	require.NoError(t, err)
+
+	err = os.MkdirAll(subDir, 0o750)
+	require.NoError(t, err)
+
+	ignoreFilePath, err := internal.DefaultIgnoreFilePath(subDir)
+
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(repoDir, ".gitignore"), ignoreFilePath)
+}
+
+func TestDefaultIgnoreFilePathShouldUseTheDirectoryOutsideOfARepository(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+
+	ignoreFilePath, err := internal.DefaultIgnoreFilePath(testDir)
+
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(testDir, ".gitignore"), ignoreFilePath)
+}
+
+func TestWriteIgnoreFileShouldCreateTheFile(t *testing.T) {
+	t.Parallel()
+
+	ignoreFilePath := filepath.Join(t.TempDir(), ".gitignore")
+
+	err := internal.WriteIgnoreFile(ignoreFilePath, "*.o\n", false)
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(ignoreFilePath)
+	require.NoError(t, err)
+	require.Equal(t, "*.o\n", string(contents))
+}
+
+func TestWriteIgnoreFileShouldNotOverwriteAnExistingFile(t *testing.T) {
+	t.Parallel()
+
+	ignoreFilePath := filepath.Join(t.TempDir(), ".gitignore")
+
+	err := os.WriteFile(ignoreFilePath, []byte("/build\n"), 0o600)
+	require.NoError(t, err)
+
+	err = internal.WriteIgnoreFile(ignoreFilePath, "*.o\n", false)
+	require.ErrorIs(t, err, internal.ErrIgnoreFileExists)
+
+	contents, err := os.ReadFile(ignoreFilePath)
+	require.NoError(t, err)
+	require.Equal(t, "/build\n", string(contents))
+}
+
+func TestWriteIgnoreFileShouldOverwriteAnExistingFileWhenForced(t *testing.T) {
+	t.Parallel()
+
+	ignoreFilePath := filepath.Join(t.TempDir(), ".gitignore")
+
+	err := os.WriteFile(ignoreFilePath, []byte("/build\n/dist\n"), 0o600)
+	require.NoError(t, err)
+
+	err = internal.WriteIgnoreFile(ignoreFilePath, "*.o\n", true)
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(ignoreFilePath)
+	require.NoError(t, err)
+	require.Equal(t, "*.o\n", string(contents))
+}
@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreKind is a flavor of ignore file. Most tools use the same
+// syntax as git but some match patterns differently so templates
+// have to be translated for them.
+type IgnoreKind struct {
+	// Name is what the kind is called on the command line, e.g.
+	// "dockerignore".
+	Name string
+
+	// FileName is the name of the ignore file, e.g. ".dockerignore".
+	FileName string
+
+	// translatePattern rewrites a single gitignore pattern for this
+	// kind of file, returning false if the pattern can't be expressed.
+	// Patterns are left as is if it is nil.
+	translatePattern func(pattern string) (string, bool)
+}
+
+// IgnoreKinds returns every supported kind of ignore file starting
+// with gitignore.
+func IgnoreKinds() []IgnoreKind {
+	return []IgnoreKind{
+		{Name: "gitignore", FileName: IgnoreFileName, translatePattern: nil},
+		{Name: "dockerignore", FileName: ".dockerignore", translatePattern: translateDockerPattern},
+		{Name: "npmignore", FileName: ".npmignore", translatePattern: nil},
+		{Name: "helmignore", FileName: ".helmignore", translatePattern: translateHelmPattern},
+		{Name: "gcloudignore", FileName: ".gcloudignore", translatePattern: nil},
+		{Name: "prettierignore", FileName: ".prettierignore", translatePattern: nil},
+		{Name: "eslintignore", FileName: ".eslintignore", translatePattern: nil},
+		{Name: "vercelignore", FileName: ".vercelignore", translatePattern: nil},
+	}
+}
+
+// LookupIgnoreKind returns the kind of ignore file with the given
+// name.
+func LookupIgnoreKind(name string) (IgnoreKind, error) {
+	names := []string{}
+
+	for _, kind := range IgnoreKinds() {
+		if strings.EqualFold(kind.Name, name) {
+			return kind, nil
+		}
+
+		names = append(names, kind.Name)
+	}
+
+	return IgnoreKinds()[0], fmt.Errorf(
+		"unknown kind of ignore file \"%s\", expected one of %s",
+		name,
+		strings.Join(names, ", "),
+	)
+}
+
+// IgnoreKindForFile returns the kind of ignore file based on its name,
+// e.g. ".dockerignore" or "Dockerfile.dockerignore". Files that don't
+// match any of the kinds are treated as gitignore files.
+func IgnoreKindForFile(filePath string) IgnoreKind {
+	name := filepath.Base(filePath)
+	kinds := IgnoreKinds()
+
+	for _, kind := range kinds {
+		if strings.HasSuffix(name, kind.FileName) {
+			return kind
+		}
+	}
+
+	return kinds[0]
+}
+
+// DefaultPath returns the path of this kind of ignore file at the root
+// of the repository containing the given directory. If the directory
+// isn't in a repository the file in the directory itself is used
+// instead.
+func (kind IgnoreKind) DefaultPath(directory string) (string, error) {
+	return repositoryFilePath(directory, kind.FileName)
+}
+
+// Translate rewrites the patterns in a generated gitignore file so
+// that they match the same files in this kind of ignore file.
+func (kind IgnoreKind) Translate(contents string) string {
+	if kind.translatePattern == nil {
+		return contents
+	}
+
+	lines := strings.Split(contents, "\n")
+
+	for index, line := range lines {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		negation, pattern := "", strings.TrimRight(line, "\r")
+		if rest, ok := strings.CutPrefix(pattern, "!"); ok {
+			negation, pattern = "!", rest
+		}
+
+		translated, ok := kind.translatePattern(pattern)
+		if !ok {
+			lines[index] = fmt.Sprintf("# Not supported by %s: %s", kind.FileName, line)
+
+			continue
+		}
+
+		lines[index] = negation + translated
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// RenderSections combines generated sections into a single ignore file
+// of this kind.
+func (kind IgnoreKind) RenderSections(sections []Section) string {
+	return kind.Translate(RenderSections(sections))
+}
+
+// translateDockerPattern rewrites a gitignore pattern for Docker.
+// Docker matches every pattern against the path from the root of the
+// build context, so patterns that gitignore matches at any depth need
+// a leading "**/". Docker also has no way to only match directories.
+func translateDockerPattern(pattern string) (string, bool) {
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	switch {
+	case strings.HasPrefix(pattern, "/"):
+		return strings.TrimPrefix(pattern, "/"), true
+	case strings.Contains(pattern, "/"):
+		return pattern, true
+	default:
+		return "**/" + pattern, true
+	}
+}
+
+// translateHelmPattern rewrites a gitignore pattern for Helm. Helm
+// already matches patterns without a slash at any depth but doesn't
+// support "**" so patterns that need it can't be translated.
+func translateHelmPattern(pattern string) (string, bool) {
+	if rest, ok := strings.CutPrefix(pattern, "**/"); ok && !strings.Contains(strings.TrimSuffix(rest, "/"), "/") {
+		pattern = rest
+	}
+
+	return pattern, !strings.Contains(pattern, "**")
+}
@@ -0,0 +1,78 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestLookupIgnoreKindShouldFindKindsByName(t *testing.T) {
+	t.Parallel()
+
+	kind, err := internal.LookupIgnoreKind("DockerIgnore")
+
+	require.NoError(t, err)
+	require.Equal(t, ".dockerignore", kind.FileName)
+}
+
+func TestLookupIgnoreKindShouldReturnAnErrorForAnUnknownKind(t *testing.T) {
+	t.Parallel()
+
+	_, err := internal.LookupIgnoreKind("svnignore")
+
+	require.ErrorContains(t, err, "dockerignore")
+}
+
+func TestIgnoreKindForFileShouldUseTheFileName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "dockerignore", internal.IgnoreKindForFile("/src/app/.dockerignore").Name)
+	require.Equal(t, "dockerignore", internal.IgnoreKindForFile("Dockerfile.dockerignore").Name)
+	require.Equal(t, "helmignore", internal.IgnoreKindForFile("charts/app/.helmignore").Name)
+	require.Equal(t, "gitignore", internal.IgnoreKindForFile("/src/app/ignore-rules").Name)
+}
+
+func TestIgnoreKindTranslateShouldLeaveGitignoreCompatibleFilesAlone(t *testing.T) {
+	t.Parallel()
+
+	kind, err := internal.LookupIgnoreKind("npmignore")
+	require.NoError(t, err)
+
+	contents := "### Node ###\nnode_modules/\n*.log\n/dist\n"
+
+	require.Equal(t, contents, kind.Translate(contents))
+}
+
+func TestIgnoreKindTranslateShouldMakeDockerPatternsMatchAtAnyDepth(t *testing.T) {
+	t.Parallel()
+
+	kind, err := internal.LookupIgnoreKind("dockerignore")
+	require.NoError(t, err)
+
+	translated := kind.Translate(
+		"### Python ###\n__pycache__/\n*.py[cod]\n/build\ndocs/_build/\n**/.venv\n!keep.pyc\n\n",
+	)
+
+	require.Equal(
+		t,
+		"### Python ###\n**/__pycache__\n**/*.py[cod]\nbuild\ndocs/_build\n**/.venv\n!**/keep.pyc\n\n",
+		translated,
+	)
+}
+
+func TestIgnoreKindTranslateShouldCommentOutPatternsHelmCanNotMatch(t *testing.T) {
+	t.Parallel()
+
+	kind, err := internal.LookupIgnoreKind("helmignore")
+	require.NoError(t, err)
+
+	translated := kind.Translate("**/.DS_Store\n*.tmp\nlogs/**/*.log\n")
+
+	require.Equal(
+		t,
+		".DS_Store\n*.tmp\n# Not supported by .helmignore: logs/**/*.log\n",
+		translated,
+	)
+}
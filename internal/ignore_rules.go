@@ -0,0 +1,113 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/durandj/git-ignore/internal/gitignore"
+)
+
+// IgnoreRules are the ignore files git reads for a repository: the
+// global excludes file, info/exclude and the .gitignore files in the
+// repository. Nested .gitignore files are loaded as paths inside of
+// their directories are matched.
+type IgnoreRules struct {
+	// Root is the root directory of the repository.
+	Root string
+
+	matcher *gitignore.Matcher
+
+	// loaded are the directories whose .gitignore file has been
+	// loaded, relative to the root.
+	loaded map[string]bool
+}
+
+// LoadIgnoreRules loads the ignore files for the repository at the
+// given root directory.
+func LoadIgnoreRules(repoRoot string) (*IgnoreRules, error) {
+	rules := &IgnoreRules{
+		Root:    repoRoot,
+		matcher: gitignore.NewMatcher(),
+		loaded:  map[string]bool{},
+	}
+
+	// Git gives the .gitignore files precedence over info/exclude and
+	// info/exclude precedence over the global excludes file.
+	excludesPath, err := GlobalExcludesFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rules.addFile(excludesPath, "")
+	if err != nil {
+		return nil, err
+	}
+
+	excludePath, err := ExcludeFilePath(repoRoot)
+	if err != nil && !errors.Is(err, ErrNotARepository) {
+		return nil, err
+	}
+
+	if err == nil {
+		err = rules.addFile(excludePath, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = rules.loadDirectory("")
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// AddPatterns adds the contents of an extra ignore file for the given
+// directory, relative to the root. It takes precedence over the files
+// that are already loaded for the directory.
+func (rules *IgnoreRules) AddPatterns(source string, directory string, contents string) {
+	rules.matcher.AddPatterns(source, directory, contents)
+}
+
+// Match returns the pattern that decides whether the path is ignored
+// or nil if no pattern matches it. The path uses slashes and is
+// relative to the root of the repository.
+func (rules *IgnoreRules) Match(filePath string, isDir bool) (*gitignore.MatchResult, error) {
+	for directory := path.Dir(filePath); directory != "." && directory != "/"; directory = path.Dir(directory) {
+		err := rules.loadDirectory(directory)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rules.matcher.Match(filePath, isDir), nil
+}
+
+// loadDirectory loads the .gitignore file in the given directory,
+// relative to the root, if it hasn't been loaded yet.
+func (rules *IgnoreRules) loadDirectory(directory string) error {
+	if rules.loaded[directory] {
+		return nil
+	}
+
+	rules.loaded[directory] = true
+
+	return rules.addFile(filepath.Join(rules.Root, filepath.FromSlash(directory), IgnoreFileName), directory)
+}
+
+// addFile adds the ignore file at the given path if it exists.
+func (rules *IgnoreRules) addFile(filePath string, directory string) error {
+	contents, err := ReadIgnoreFile(filePath)
+	if err != nil {
+		return fmt.Errorf("unable to load ignore rules: %w", err)
+	}
+
+	if contents != "" {
+		rules.matcher.AddPatterns(filePath, directory, contents)
+	}
+
+	return nil
+}
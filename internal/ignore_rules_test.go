@@ -0,0 +1,73 @@
+package internal_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+//nolint:paralleltest // Modifies the environment
+func TestIgnoreRulesShouldGiveGitignoreFilesPrecedence(t *testing.T) {
+	home := setGitEnvironment(t)
+	repoDir := t.TempDir()
+
+	writeTemplates(t, home, map[string]string{
+		filepath.Join(".config", "git", "ignore"): "*.log\n*.swp\n",
+	})
+	writeTemplates(t, repoDir, map[string]string{
+		filepath.Join(".git", "info", "exclude"): "!debug.log\n*.tmp\n",
+		".gitignore":                             "!notes.tmp\n",
+	})
+
+	rules, err := internal.LoadIgnoreRules(repoDir)
+	require.NoError(t, err)
+
+	match, err := rules.Match("main.swp", false)
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	require.True(t, match.Ignored())
+	require.Equal(t, filepath.Join(home, ".config", "git", "ignore"), match.File.Source)
+
+	match, err = rules.Match("debug.log", false)
+	require.NoError(t, err)
+	require.False(t, match.Ignored())
+	require.Equal(t, filepath.Join(repoDir, ".git", "info", "exclude"), match.File.Source)
+
+	match, err = rules.Match("notes.tmp", false)
+	require.NoError(t, err)
+	require.False(t, match.Ignored())
+	require.Equal(t, filepath.Join(repoDir, ".gitignore"), match.File.Source)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestIgnoreRulesShouldLoadNestedGitignoreFiles(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := t.TempDir()
+
+	writeTemplates(t, repoDir, map[string]string{
+		".gitignore":                             "*.gen.go\n",
+		filepath.Join("api", ".gitignore"):       "!*.gen.go\n",
+		filepath.Join("web", ".gitignore"):       "/dist/\n",
+		filepath.Join(".git", "info", "exclude"): "",
+	})
+
+	rules, err := internal.LoadIgnoreRules(repoDir)
+	require.NoError(t, err)
+
+	match, err := rules.Match("api/v1/types.gen.go", false)
+	require.NoError(t, err)
+	require.False(t, match.Ignored())
+	require.Equal(t, filepath.Join(repoDir, "api", ".gitignore"), match.File.Source)
+
+	match, err = rules.Match("web/dist/index.js", false)
+	require.NoError(t, err)
+	require.True(t, match.Ignored())
+	require.Equal(t, "web/dist", match.Path)
+
+	match, err = rules.Match("dist/index.js", false)
+	require.NoError(t, err)
+	require.Nil(t, match)
+}
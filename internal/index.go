@@ -0,0 +1,254 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// indexVersion is bumped whenever the on-disk index format changes so
+// that older indexes are rebuilt rather than misread.
+const indexVersion = 1
+
+// templateIndex maps options to the exact path of the template that
+// provides them.
+type templateIndex struct {
+	// paths are the paths of every template relative to the template
+	// directory and without the file extension, e.g.
+	// "community/Golang/Hugo".
+	paths []string
+
+	// byName maps a template name to all the paths with that name.
+	byName map[string][]string
+
+	// byFoldedName and byFoldedPath are the lower case equivalents of
+	// byName and paths and are used for case-insensitive lookups.
+	byFoldedName map[string][]string
+	byFoldedPath map[string]string
+
+	// templates maps a template path to its metadata.
+	templates map[string]indexedTemplate
+
+	// revision is the version of the templates that were indexed, e.g.
+	// a commit hash. It is empty if the templates aren't versioned.
+	revision string
+}
+
+// indexFile is the on-disk form of a template index.
+type indexFile struct {
+	Version   int               `json:"version"`
+	Revision  string            `json:"revision"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+	Templates []indexedTemplate `json:"templates"`
+}
+
+// indexedTemplate is the metadata stored for each template.
+type indexedTemplate struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Hash string `json:"hash,omitempty"`
+	Size int64  `json:"size"`
+}
+
+// buildTemplateIndex indexes every gitignore template found under the
+// given directory. Content hashes are only calculated when requested
+// since they require reading every template.
+func buildTemplateIndex(directory string, withHashes bool) (*templateIndex, error) {
+	templates := []indexedTemplate{}
+
+	err := filepath.Walk(directory, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("unable to file gitignore files: %w", err)
+		}
+
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		if info.IsDir() || path.Ext(filePath) != templateExtension {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(directory, filePath)
+		if err != nil {
+			return fmt.Errorf("unable to determine relative path of %s: %w", filePath, err)
+		}
+
+		templatePath := strings.TrimSuffix(filepath.ToSlash(relativePath), templateExtension)
+		template := indexedTemplate{
+			Name: path.Base(templatePath),
+			Path: templatePath,
+			Hash: "",
+			Size: info.Size(),
+		}
+
+		if withHashes {
+			contents, err := os.ReadFile(filePath)
+			if err != nil {
+				return fmt.Errorf("unable to read %s: %w", filePath, err)
+			}
+
+			template.Hash = hashContent(contents)
+		}
+
+		templates = append(templates, template)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read gitignore directory: %w", err)
+	}
+
+	return newTemplateIndex(templates, ""), nil
+}
+
+func newTemplateIndex(templates []indexedTemplate, revision string) *templateIndex {
+	index := &templateIndex{
+		paths:        make([]string, 0, len(templates)),
+		byName:       map[string][]string{},
+		byFoldedName: map[string][]string{},
+		byFoldedPath: make(map[string]string, len(templates)),
+		templates:    make(map[string]indexedTemplate, len(templates)),
+		revision:     revision,
+	}
+
+	for _, template := range templates {
+		index.paths = append(index.paths, template.Path)
+		index.byName[template.Name] = append(index.byName[template.Name], template.Path)
+		index.templates[template.Path] = template
+
+		foldedName := strings.ToLower(template.Name)
+		index.byFoldedName[foldedName] = append(index.byFoldedName[foldedName], template.Path)
+		index.byFoldedPath[strings.ToLower(template.Path)] = template.Path
+	}
+
+	slices.Sort(index.paths)
+
+	for _, templatePaths := range index.byName {
+		slices.Sort(templatePaths)
+	}
+
+	for _, templatePaths := range index.byFoldedName {
+		slices.Sort(templatePaths)
+	}
+
+	return index
+}
+
+// readTemplateIndex loads an index previously saved with
+// writeTemplateIndex. It returns nil if there is no usable index at
+// the given path.
+func readTemplateIndex(indexPath string) (*templateIndex, error) {
+	contents, err := os.ReadFile(indexPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template index: %w", err)
+	}
+
+	//nolint:exhaustruct // Populated by the decoder
+	file := indexFile{}
+
+	err = json.Unmarshal(contents, &file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template index: %w", err)
+	}
+
+	if file.Version != indexVersion {
+		return nil, nil
+	}
+
+	return newTemplateIndex(file.Templates, file.Revision), nil
+}
+
+// writeTemplateIndex saves the index so it can be loaded without
+// walking the template directory.
+func writeTemplateIndex(indexPath string, index *templateIndex) error {
+	file := indexFile{
+		Version:   indexVersion,
+		Revision:  index.revision,
+		UpdatedAt: time.Now().UTC(),
+		Templates: make([]indexedTemplate, 0, len(index.paths)),
+	}
+
+	for _, templatePath := range index.paths {
+		file.Templates = append(file.Templates, index.templates[templatePath])
+	}
+
+	contents, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize template index: %w", err)
+	}
+
+	err = os.WriteFile(indexPath, contents, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to write template index: %w", err)
+	}
+
+	return nil
+}
+
+// names returns the name of every template. Names shared by more
+// than one template are only returned once.
+func (index *templateIndex) names() []string {
+	names := make([]string, 0, len(index.byName))
+	for name := range index.byName {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// resolve finds the path of the template for the given option. The
+// option must either match a template's path or the name of exactly
+// one template. An exact match of a template's path always wins,
+// after that exact matches are preferred but if there aren't any the
+// option is matched without regard to case.
+func (index *templateIndex) resolve(option string) (string, error) {
+	if _, found := index.templates[option]; found {
+		return option, nil
+	}
+
+	if strings.Contains(option, "/") {
+		if templatePath, found := index.byFoldedPath[strings.ToLower(option)]; found {
+			return templatePath, nil
+		}
+
+		return "", fmt.Errorf("invalid option \"%s\"", option)
+	}
+
+	candidates := index.byName[option]
+	if len(candidates) == 0 {
+		candidates = index.byFoldedName[strings.ToLower(option)]
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("invalid option \"%s\"", option)
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", &AmbiguousOptionError{
+			Option:     option,
+			Candidates: slices.Clone(candidates),
+		}
+	}
+}
+
+func hashContent(contents []byte) string {
+	sum := sha256.Sum256(contents)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
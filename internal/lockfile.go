@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// LockfileSuffix is added to the name of an ignore file to get the
+// name of its lockfile, e.g. ".gitignore.lock".
+const LockfileSuffix string = ".lock"
+
+const lockfileVersion = 1
+
+// Lockfile records exactly where each section of a generated ignore
+// file came from so that it can be reproduced later.
+type Lockfile struct {
+	Version   int              `json:"version"`
+	Templates []LockedTemplate `json:"templates"`
+}
+
+// LockedTemplate records where the section for a single option came
+// from.
+type LockedTemplate struct {
+	// Option is the option as it was requested.
+	Option string `json:"option"`
+
+	// Source is the name of the adapter that generated the section.
+	Source string `json:"source"`
+
+	Path     string `json:"path,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Revision string `json:"revision,omitempty"`
+
+	// Hash is the hash of the generated section.
+	Hash string `json:"hash"`
+}
+
+// NewLockfile creates a lockfile for the given generated sections.
+func NewLockfile(sections []Section) *Lockfile {
+	lockfile := &Lockfile{
+		Version:   lockfileVersion,
+		Templates: make([]LockedTemplate, 0, len(sections)),
+	}
+
+	for _, section := range sections {
+		lockfile.Templates = append(lockfile.Templates, LockedTemplate{
+			Option:   section.Option,
+			Source:   section.Source,
+			Path:     section.Template.Path,
+			URL:      section.Template.URL,
+			Revision: section.Template.Revision,
+			Hash:     hashContent([]byte(section.Content)),
+		})
+	}
+
+	return lockfile
+}
+
+// LockfilePath returns the path of the lockfile for the given ignore
+// file.
+func LockfilePath(ignoreFilePath string) string {
+	return ignoreFilePath + LockfileSuffix
+}
+
+// Options returns the options that were locked in the order they
+// were requested.
+func (lockfile *Lockfile) Options() []string {
+	options := make([]string, 0, len(lockfile.Templates))
+	for _, template := range lockfile.Templates {
+		options = append(options, template.Option)
+	}
+
+	return options
+}
+
+// ReadLockfile reads the lockfile at the given path. It returns nil if
+// the lockfile doesn't exist.
+func ReadLockfile(lockfilePath string) (*Lockfile, error) {
+	contents, err := os.ReadFile(lockfilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read lockfile %s: %w", lockfilePath, err)
+	}
+
+	//nolint:exhaustruct // Populated by the decoder
+	lockfile := &Lockfile{}
+
+	err = json.Unmarshal(contents, lockfile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse lockfile %s: %w", lockfilePath, err)
+	}
+
+	if lockfile.Version != lockfileVersion {
+		return nil, fmt.Errorf("unsupported lockfile version %d in %s", lockfile.Version, lockfilePath)
+	}
+
+	return lockfile, nil
+}
+
+// Write saves the lockfile to the given path.
+func (lockfile *Lockfile) Write(lockfilePath string) error {
+	contents, err := json.MarshalIndent(lockfile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize lockfile: %w", err)
+	}
+
+	contents = append(contents, '\n')
+
+	//nolint:gosec // Lockfiles are committed alongside the ignore file
+	err = os.WriteFile(lockfilePath, contents, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to write lockfile %s: %w", lockfilePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package internal_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestNewLockfileShouldRecordEachSection(t *testing.T) {
+	t.Parallel()
+
+	lockfile := internal.NewLockfile([]internal.Section{
+		{
+			Option: "Go",
+			Source: "github",
+			Template: internal.TemplateInfo{
+				Path:     "Go",
+				URL:      internal.DefaultGitRepo,
+				Revision: "0123456789abcdef0123456789abcdef01234567",
+			},
+			Content: "*.exe\n",
+		},
+	})
+
+	require.Equal(t, []internal.LockedTemplate{
+		{
+			Option:   "Go",
+			Source:   "github",
+			Path:     "Go",
+			URL:      internal.DefaultGitRepo,
+			Revision: "0123456789abcdef0123456789abcdef01234567",
+			Hash:     "sha256:a5270f91138fc2bb5470ecb521dab043140d7e0fd8cb33bb0644ac13efb60fe7",
+		},
+	}, lockfile.Templates)
+	require.Equal(t, []string{"Go"}, lockfile.Options())
+}
+
+func TestLockfileShouldBeReadableAfterBeingWritten(t *testing.T) {
+	t.Parallel()
+
+	lockfilePath := internal.LockfilePath(filepath.Join(t.TempDir(), ".gitignore"))
+	lockfile := internal.NewLockfile([]internal.Section{
+		{
+			Option:   "Go",
+			Source:   "github",
+			Template: internal.TemplateInfo{Path: "Go", URL: internal.DefaultGitRepo, Revision: "abc123"},
+			Content:  "*.exe\n",
+		},
+	})
+
+	err := lockfile.Write(lockfilePath)
+	require.NoError(t, err)
+
+	readLockfile, err := internal.ReadLockfile(lockfilePath)
+
+	require.NoError(t, err)
+	require.Equal(t, lockfile, readLockfile)
+	require.Equal(t, ".gitignore.lock", filepath.Base(lockfilePath))
+}
+
+func TestReadLockfileShouldReturnNilWhenThereIsNoLockfile(t *testing.T) {
+	t.Parallel()
+
+	lockfile, err := internal.ReadLockfile(filepath.Join(t.TempDir(), ".gitignore.lock"))
+
+	require.NoError(t, err)
+	require.Nil(t, lockfile)
+}
+
+func TestClientGenerateLockedShouldReproduceTheLockedTemplates(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"C.gitignore": "*.o\n",
+	})
+	client := internal.Client{
+		Adapters: []internal.Adapter{adapter},
+		Aliases:  nil,
+	}
+
+	sections, err := client.GenerateSections([]string{"c"})
+	require.NoError(t, err)
+
+	lockfile := internal.NewLockfile(sections)
+
+	commitTemplates(t, adapter.RepoURL, map[string]string{
+		"C.gitignore": "*.o\n*.a\n",
+	})
+
+	err = adapter.Update()
+	require.NoError(t, err)
+
+	lockedSections, err := client.GenerateLocked(lockfile)
+
+	require.NoError(t, err)
+	require.Equal(t, sections, lockedSections)
+	require.Equal(t, lockfile, internal.NewLockfile(lockedSections))
+
+	contents, err := client.Generate([]string{"c"})
+
+	require.NoError(t, err)
+	require.Equal(t, "### C ###\n*.o\n*.a\n\n", contents)
+}
+
+func TestClientGenerateLockedShouldReturnAnErrorForAnUnknownSource(t *testing.T) {
+	t.Parallel()
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{},
+		Aliases:  nil,
+	}
+
+	_, err := client.GenerateLocked(&internal.Lockfile{
+		Version: 1,
+		Templates: []internal.LockedTemplate{
+			{Option: "C", Source: "github", Path: "C", URL: "", Revision: "", Hash: ""},
+		},
+	})
+
+	require.Error(t, err)
+}
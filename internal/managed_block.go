@@ -0,0 +1,222 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	managedBlockStartPrefix = "# >>> git-ignore:"
+	managedBlockStartSuffix = ">>>"
+	managedBlockEnd         = "# <<< git-ignore <<<"
+)
+
+// ErrMalformedManagedBlock is returned when an ignore file has a
+// managed block that can't be parsed.
+var ErrMalformedManagedBlock = errors.New("malformed git-ignore block")
+
+// ManagedBlock is the section of an ignore file that was generated by
+// git-ignore. Everything outside of the block is left alone when the
+// block is regenerated.
+type ManagedBlock struct {
+	// Options are the options the block was generated with.
+	Options []string
+
+	// Content is everything between the block's markers.
+	Content string
+}
+
+// FindManagedBlock finds the managed block in the contents of an
+// ignore file. It returns nil if there isn't one.
+func FindManagedBlock(contents string) (*ManagedBlock, error) {
+	lines := strings.Split(contents, "\n")
+
+	start, end, err := findManagedBlockLines(lines)
+	if err != nil || start < 0 {
+		return nil, err
+	}
+
+	options := parseManagedBlockStart(lines[start])
+	content := strings.Join(lines[start+1:end], "\n")
+
+	if content != "" {
+		content += "\n"
+	}
+
+	return &ManagedBlock{
+		Options: options,
+		Content: content,
+	}, nil
+}
+
+// MergeManagedBlock replaces the managed block in the contents of an
+// ignore file with a new block for the given options. If there isn't
+// a managed block yet then the new block is added to the end of the
+// file.
+func MergeManagedBlock(contents string, options []string, generated string) (string, error) {
+	block := RenderManagedBlock(options, generated)
+	lines := strings.Split(contents, "\n")
+
+	start, end, err := findManagedBlockLines(lines)
+	if err != nil {
+		return "", err
+	}
+
+	if start < 0 {
+		if strings.TrimSpace(contents) == "" {
+			return block, nil
+		}
+
+		return strings.TrimRight(contents, "\n") + "\n\n" + block, nil
+	}
+
+	before := strings.Join(lines[:start], "\n")
+	after := strings.Join(lines[end+1:], "\n")
+
+	if before != "" {
+		before += "\n"
+	}
+
+	return before + block + after, nil
+}
+
+// RemoveManagedBlock removes the managed block from the contents of an
+// ignore file, leaving everything else as is.
+func RemoveManagedBlock(contents string) (string, error) {
+	lines := strings.Split(contents, "\n")
+
+	start, end, err := findManagedBlockLines(lines)
+	if err != nil || start < 0 {
+		return contents, err
+	}
+
+	before := strings.TrimRight(strings.Join(lines[:start], "\n"), "\n")
+	after := strings.TrimLeft(strings.Join(lines[end+1:], "\n"), "\n")
+
+	switch {
+	case before == "":
+		return after, nil
+	case after == "":
+		return before + "\n", nil
+	default:
+		return before + "\n\n" + after, nil
+	}
+}
+
+// AddOptions returns the current options with the new options added
+// to the end. Options that are already present are skipped.
+func AddOptions(current []string, added []string) []string {
+	options := slices.Clone(current)
+
+	for _, option := range added {
+		if !containsOption(options, option) {
+			options = append(options, option)
+		}
+	}
+
+	return options
+}
+
+// RemoveOptions returns the current options without the removed
+// options along with any of the removed options that weren't present.
+func RemoveOptions(current []string, removed []string) ([]string, []string) {
+	options := []string{}
+
+	for _, option := range current {
+		if !containsOption(removed, option) {
+			options = append(options, option)
+		}
+	}
+
+	missing := []string{}
+
+	for _, option := range removed {
+		if !containsOption(current, option) {
+			missing = append(missing, option)
+		}
+	}
+
+	return options, missing
+}
+
+func containsOption(options []string, option string) bool {
+	return slices.ContainsFunc(options, func(candidate string) bool {
+		return strings.EqualFold(candidate, option)
+	})
+}
+
+// RenderManagedBlock wraps the generated content in the markers for a
+// managed block.
+func RenderManagedBlock(options []string, generated string) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf(
+		"%s %s %s\n",
+		managedBlockStartPrefix,
+		strings.Join(options, ", "),
+		managedBlockStartSuffix,
+	))
+
+	generated = strings.TrimRight(generated, "\n")
+	if generated != "" {
+		builder.WriteString(generated)
+		builder.WriteString("\n")
+	}
+
+	builder.WriteString(managedBlockEnd)
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+// findManagedBlockLines returns the line numbers of the start and end
+// markers of the managed block. Both are -1 if there isn't a block.
+func findManagedBlockLines(lines []string) (int, int, error) {
+	start := -1
+	end := -1
+
+	for lineNumber, line := range lines {
+		line = strings.TrimRight(line, "\r ")
+
+		switch {
+		case strings.HasPrefix(line, managedBlockStartPrefix):
+			if start >= 0 {
+				return -1, -1, fmt.Errorf("%w: found more than one block", ErrMalformedManagedBlock)
+			}
+
+			start = lineNumber
+
+		case line == managedBlockEnd:
+			if start < 0 || end >= 0 {
+				return -1, -1, fmt.Errorf("%w: unexpected end marker on line %d", ErrMalformedManagedBlock, lineNumber+1)
+			}
+
+			end = lineNumber
+		}
+	}
+
+	if start >= 0 && end < 0 {
+		return -1, -1, fmt.Errorf("%w: missing end marker", ErrMalformedManagedBlock)
+	}
+
+	return start, end, nil
+}
+
+func parseManagedBlockStart(line string) []string {
+	line = strings.TrimRight(line, "\r ")
+	line = strings.TrimPrefix(line, managedBlockStartPrefix)
+	line = strings.TrimSuffix(line, managedBlockStartSuffix)
+
+	options := []string{}
+
+	for _, option := range strings.Split(line, ",") {
+		option = strings.TrimSpace(option)
+		if option != "" {
+			options = append(options, option)
+		}
+	}
+
+	return options
+}
@@ -0,0 +1,116 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestMergeManagedBlockShouldCreateABlockInAnEmptyFile(t *testing.T) {
+	t.Parallel()
+
+	contents, err := internal.MergeManagedBlock("", []string{"Go", "Node"}, "### Go ###\n*.exe\n\n")
+
+	require.NoError(t, err)
+	require.Equal(t, "# >>> git-ignore: Go, Node >>>\n### Go ###\n*.exe\n# <<< git-ignore <<<\n", contents)
+}
+
+func TestMergeManagedBlockShouldAppendABlockToAnExistingFile(t *testing.T) {
+	t.Parallel()
+
+	contents, err := internal.MergeManagedBlock("/build\n/dist\n", []string{"Go"}, "*.exe\n")
+
+	require.NoError(t, err)
+	require.Equal(t, "/build\n/dist\n\n# >>> git-ignore: Go >>>\n*.exe\n# <<< git-ignore <<<\n", contents)
+}
+
+func TestMergeManagedBlockShouldOnlyReplaceTheExistingBlock(t *testing.T) {
+	t.Parallel()
+
+	existing := "/build\n\n# >>> git-ignore: Go >>>\n*.exe\n# <<< git-ignore <<<\n\n# Local files\n/secrets\n"
+
+	contents, err := internal.MergeManagedBlock(existing, []string{"Go", "Node"}, "*.exe\nnode_modules/\n")
+
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		"/build\n\n# >>> git-ignore: Go, Node >>>\n*.exe\nnode_modules/\n# <<< git-ignore <<<\n\n# Local files\n/secrets\n",
+		contents,
+	)
+}
+
+func TestMergeManagedBlockShouldReturnAnErrorForAnUnterminatedBlock(t *testing.T) {
+	t.Parallel()
+
+	_, err := internal.MergeManagedBlock("# >>> git-ignore: Go >>>\n*.exe\n", []string{"Go"}, "*.exe\n")
+
+	require.ErrorIs(t, err, internal.ErrMalformedManagedBlock)
+}
+
+func TestMergeManagedBlockShouldReturnAnErrorForMultipleBlocks(t *testing.T) {
+	t.Parallel()
+
+	existing := "# >>> git-ignore: Go >>>\n# <<< git-ignore <<<\n# >>> git-ignore: Go >>>\n# <<< git-ignore <<<\n"
+
+	_, err := internal.MergeManagedBlock(existing, []string{"Go"}, "*.exe\n")
+
+	require.ErrorIs(t, err, internal.ErrMalformedManagedBlock)
+}
+
+func TestFindManagedBlockShouldReturnTheOptionsAndContent(t *testing.T) {
+	t.Parallel()
+
+	block, err := internal.FindManagedBlock("/build\n# >>> git-ignore: Go, github:Node >>>\n*.exe\n# <<< git-ignore <<<\n")
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go", "github:Node"}, block.Options)
+	require.Equal(t, "*.exe\n", block.Content)
+}
+
+func TestFindManagedBlockShouldReturnNilWithoutABlock(t *testing.T) {
+	t.Parallel()
+
+	block, err := internal.FindManagedBlock("/build\n")
+
+	require.NoError(t, err)
+	require.Nil(t, block)
+}
+
+func TestRemoveManagedBlockShouldKeepEverythingOutsideOfTheBlock(t *testing.T) {
+	t.Parallel()
+
+	existing := "/build\n\n# >>> git-ignore: Go >>>\n*.exe\n# <<< git-ignore <<<\n\n# Local files\n/secrets\n"
+
+	contents, err := internal.RemoveManagedBlock(existing)
+
+	require.NoError(t, err)
+	require.Equal(t, "/build\n\n# Local files\n/secrets\n", contents)
+}
+
+func TestRemoveManagedBlockShouldLeaveAnEmptyFileWhenThereIsOnlyABlock(t *testing.T) {
+	t.Parallel()
+
+	contents, err := internal.RemoveManagedBlock("# >>> git-ignore: Go >>>\n*.exe\n# <<< git-ignore <<<\n")
+
+	require.NoError(t, err)
+	require.Empty(t, contents)
+}
+
+func TestAddOptionsShouldSkipOptionsThatAreAlreadyPresent(t *testing.T) {
+	t.Parallel()
+
+	options := internal.AddOptions([]string{"Go", "Node"}, []string{"node", "VisualStudioCode"})
+
+	require.Equal(t, []string{"Go", "Node", "VisualStudioCode"}, options)
+}
+
+func TestRemoveOptionsShouldReportOptionsThatWereNotPresent(t *testing.T) {
+	t.Parallel()
+
+	options, missing := internal.RemoveOptions([]string{"Go", "Node"}, []string{"node", "Python"})
+
+	require.Equal(t, []string{"Go"}, options)
+	require.Equal(t, []string{"Python"}, missing)
+}
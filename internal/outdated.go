@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"fmt"
+)
+
+// OutdatedTemplate is a locked template that has changed upstream
+// since it was locked.
+type OutdatedTemplate struct {
+	Template LockedTemplate
+
+	// Changes are the upstream changes to the template since the
+	// locked revision, newest first. Sources without history only
+	// know that the template changed so they don't have any.
+	Changes []TemplateChange
+}
+
+// Outdated returns the locked templates that have changed upstream.
+// Sources with history are compared against the locked revision while
+// everything else is regenerated and compared against the locked hash.
+// Sources should be updated first to find the latest changes.
+func (client *Client) Outdated(lockfile *Lockfile) ([]OutdatedTemplate, error) {
+	outdated := []OutdatedTemplate{}
+
+	for _, template := range lockfile.Templates {
+		adapter, name, err := client.lockedAdapter(template)
+		if err != nil {
+			return nil, err
+		}
+
+		historian, ok := adapter.(Historian)
+		if ok && template.Revision != "" && template.Path != "" {
+			changes, err := historian.Changes(template.Path, template.Revision)
+			if err != nil {
+				return nil, fmt.Errorf("unable to find changes to %s: %w", template.Option, err)
+			}
+
+			if len(changes) > 0 {
+				outdated = append(outdated, OutdatedTemplate{Template: template, Changes: changes})
+			}
+
+			continue
+		}
+
+		content, err := adapter.Generate([]string{name})
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate %s: %w", template.Option, err)
+		}
+
+		if hashContent([]byte(content)) != template.Hash {
+			outdated = append(outdated, OutdatedTemplate{Template: template, Changes: []TemplateChange{}})
+		}
+	}
+
+	return outdated, nil
+}
@@ -0,0 +1,73 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestClientOutdatedShouldListTemplatesChangedSinceTheLockedRevision(t *testing.T) {
+	t.Parallel()
+
+	adapter := newLocalGitAdapter(t, map[string]string{
+		"C.gitignore":      "*.o\n",
+		"Python.gitignore": "__pycache__/\n",
+	})
+	client := internal.Client{
+		Adapters: []internal.Adapter{adapter},
+		Aliases:  nil,
+	}
+
+	sections, err := client.GenerateSections([]string{"C", "Python"})
+	require.NoError(t, err)
+
+	lockfile := internal.NewLockfile(sections)
+
+	revision := commitTemplates(t, adapter.RepoURL, map[string]string{
+		"C.gitignore": "*.o\n*.a\n",
+	})
+
+	err = adapter.Update()
+	require.NoError(t, err)
+
+	outdated, err := client.Outdated(lockfile)
+
+	require.NoError(t, err)
+	require.Len(t, outdated, 1)
+	require.Equal(t, lockfile.Templates[0], outdated[0].Template)
+	require.Len(t, outdated[0].Changes, 1)
+	require.Equal(t, revision, outdated[0].Changes[0].Revision)
+}
+
+func TestClientOutdatedShouldCompareHashesForSourcesWithoutHistory(t *testing.T) {
+	t.Parallel()
+
+	testDir := t.TempDir()
+	writeTemplates(t, testDir, map[string]string{
+		"C.gitignore":      "*.o\n",
+		"Python.gitignore": "__pycache__/\n",
+	})
+
+	client := internal.Client{
+		Adapters: []internal.Adapter{internal.NewDirectoryAdapter("internal", testDir)},
+		Aliases:  nil,
+	}
+
+	sections, err := client.GenerateSections([]string{"C", "Python"})
+	require.NoError(t, err)
+
+	lockfile := internal.NewLockfile(sections)
+
+	writeTemplates(t, testDir, map[string]string{
+		"Python.gitignore": "__pycache__/\n*.pyc\n",
+	})
+
+	outdated, err := client.Outdated(lockfile)
+
+	require.NoError(t, err)
+	require.Equal(t, []internal.OutdatedTemplate{
+		{Template: lockfile.Templates[1], Changes: []internal.TemplateChange{}},
+	}, outdated)
+}
@@ -0,0 +1,271 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5/plumbing/format/index"
+
+	"github.com/durandj/git-ignore/internal/gitignore"
+)
+
+// PreviewedPath is an existing path that isn't ignored yet but would
+// be if new templates were added.
+type PreviewedPath struct {
+	// Path is relative to the root of the repository and uses slashes.
+	// Directories end with a slash.
+	Path string
+
+	// Option is the option whose template would ignore the path.
+	Option string
+
+	Pattern gitignore.Pattern
+
+	// Tracked is true for files that git already tracks. Ignore rules
+	// don't apply to them so they would stay tracked.
+	Tracked bool
+}
+
+// PreviewIgnored finds the files and directories in the repository
+// that would become ignored if the given sections were added to the
+// root .gitignore file. Untracked paths are listed first, stopping at
+// directories that would be ignored as a whole, followed by every
+// tracked file the sections would match.
+func PreviewIgnored(repoRoot string, sections []Section) ([]PreviewedPath, error) {
+	current, err := LoadIgnoreRules(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	proposed, err := LoadIgnoreRules(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, section := range sections {
+		proposed.AddPatterns(section.Option, "", section.Content)
+	}
+
+	tracked, err := readTrackedFiles(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	trackedPaths := map[string]bool{}
+	for _, trackedPath := range tracked {
+		trackedPaths[trackedPath] = true
+	}
+
+	previewed, err := previewUntracked(repoRoot, current, proposed, trackedPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trackedPath := range tracked {
+		path, _, err := previewPath(current, proposed, trackedPath, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if path != nil {
+			path.Tracked = true
+			previewed = append(previewed, *path)
+		}
+	}
+
+	return previewed, nil
+}
+
+// previewUntracked walks the working tree looking for untracked paths
+// that the proposed rules would ignore.
+func previewUntracked(
+	repoRoot string,
+	current *IgnoreRules,
+	proposed *IgnoreRules,
+	trackedPaths map[string]bool,
+) ([]PreviewedPath, error) {
+	previewed := []PreviewedPath{}
+
+	err := filepath.WalkDir(repoRoot, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if filePath == repoRoot {
+			return nil
+		}
+
+		if entry.Name() == ".git" {
+			return skipEntry(entry)
+		}
+
+		relativePath, err := filepath.Rel(repoRoot, filePath)
+		if err != nil {
+			return fmt.Errorf("unable to resolve %s: %w", filePath, err)
+		}
+
+		relativePath = filepath.ToSlash(relativePath)
+		if trackedPaths[relativePath] {
+			return nil
+		}
+
+		path, ignored, err := previewPath(current, proposed, relativePath, entry.IsDir())
+		if err != nil || !ignored {
+			return err
+		}
+
+		if path == nil {
+			return skipEntry(entry)
+		}
+
+		// Everything inside of an ignored directory is ignored too so
+		// there's no need to look any further. Directories that only
+		// have tracked files in them don't hide anything though, their
+		// files are listed with the rest of the tracked ones.
+		if entry.IsDir() {
+			untracked, err := hasUntrackedFiles(repoRoot, relativePath, trackedPaths)
+			if err != nil {
+				return err
+			}
+
+			if !untracked {
+				return filepath.SkipDir
+			}
+
+			path.Path += "/"
+		}
+
+		previewed = append(previewed, *path)
+
+		return skipEntry(entry)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan %s: %w", repoRoot, err)
+	}
+
+	return previewed, nil
+}
+
+// hasUntrackedFiles reports whether the directory, relative to the
+// root of the repository, has any files in it that git doesn't track.
+func hasUntrackedFiles(repoRoot string, directory string, trackedPaths map[string]bool) (bool, error) {
+	untracked := false
+
+	err := filepath.WalkDir(
+		filepath.Join(repoRoot, filepath.FromSlash(directory)),
+		func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.Name() == ".git" {
+				return skipEntry(entry)
+			}
+
+			if entry.IsDir() {
+				return nil
+			}
+
+			relativePath, err := filepath.Rel(repoRoot, filePath)
+			if err != nil {
+				return fmt.Errorf("unable to resolve %s: %w", filePath, err)
+			}
+
+			if !trackedPaths[filepath.ToSlash(relativePath)] {
+				untracked = true
+
+				return filepath.SkipAll
+			}
+
+			return nil
+		},
+	)
+
+	return untracked, err
+}
+
+// previewPath returns the path if the proposed rules would ignore it
+// while the current ones don't, or nil otherwise. It also reports
+// whether the proposed rules ignore the path at all.
+func previewPath(
+	current *IgnoreRules,
+	proposed *IgnoreRules,
+	filePath string,
+	isDir bool,
+) (*PreviewedPath, bool, error) {
+	proposedMatch, err := proposed.Match(filePath, isDir)
+	if err != nil || proposedMatch == nil || !proposedMatch.Ignored() {
+		return nil, false, err
+	}
+
+	currentMatch, err := current.Match(filePath, isDir)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if currentMatch != nil && currentMatch.Ignored() {
+		return nil, true, nil
+	}
+
+	return &PreviewedPath{
+		Path:    filePath,
+		Option:  proposedMatch.File.Source,
+		Pattern: proposedMatch.Pattern,
+		Tracked: false,
+	}, true, nil
+}
+
+// skipEntry skips the rest of a directory when walking the working
+// tree. Files don't need to be skipped.
+func skipEntry(entry fs.DirEntry) error {
+	if entry.IsDir() {
+		return filepath.SkipDir
+	}
+
+	return nil
+}
+
+// readTrackedFiles returns the paths of the files in the git index of
+// the repository at the given root. Paths use slashes and are relative
+// to the root.
+func readTrackedFiles(repoRoot string) ([]string, error) {
+	gitDir, err := FindGitDir(repoRoot)
+	if errors.Is(err, ErrNotARepository) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	indexPath := filepath.Join(gitDir, "index")
+
+	// New repositories don't have an index until something is staged.
+	file, err := os.Open(indexPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the git index %s: %w", indexPath, err)
+	}
+	defer file.Close()
+
+	//nolint:exhaustruct // Populated by the decoder
+	gitIndex := &index.Index{}
+
+	err = index.NewDecoder(file).Decode(gitIndex)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the git index %s: %w", indexPath, err)
+	}
+
+	tracked := make([]string, 0, len(gitIndex.Entries))
+	for _, entry := range gitIndex.Entries {
+		tracked = append(tracked, entry.Name)
+	}
+
+	return tracked, nil
+}
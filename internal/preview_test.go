@@ -0,0 +1,106 @@
+package internal_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+//nolint:paralleltest // Modifies the environment
+func TestPreviewIgnoredShouldListUntrackedPathsThatWouldBeIgnored(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := t.TempDir()
+
+	writeTemplates(t, repoDir, map[string]string{
+		filepath.Join(".git", "info", "exclude"): "",
+		".gitignore":                             "/build/\n",
+		"main.go":                                "package main\n",
+		"server.test":                            "",
+		filepath.Join("build", "server.test"):    "",
+		filepath.Join("web", "node_modules", "a", "a.js"): "",
+		filepath.Join("web", "index.js"):                  "",
+	})
+
+	previewed, err := internal.PreviewIgnored(repoDir, []internal.Section{
+		newSection("Go", "### Go ###\n*.test\n"),
+		newSection("Node", "### Node ###\nnode_modules/\n"),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, previewed, 2)
+	require.Equal(t, "server.test", previewed[0].Path)
+	require.Equal(t, "Go", previewed[0].Option)
+	require.Equal(t, "*.test", previewed[0].Pattern.Text)
+	require.False(t, previewed[0].Tracked)
+	require.Equal(t, "web/node_modules/", previewed[1].Path)
+	require.Equal(t, "Node", previewed[1].Option)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestPreviewIgnoredShouldListTrackedFilesThatWouldBeIgnored(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := newTemplateRepository(t, map[string]string{
+		"main.go":                             "package main\n",
+		filepath.Join("testdata", "app.test"): "",
+	})
+
+	writeTemplates(t, repoDir, map[string]string{"local.test": ""})
+
+	previewed, err := internal.PreviewIgnored(repoDir, []internal.Section{
+		newSection("Go", "### Go ###\n*.test\n"),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, previewed, 2)
+	require.Equal(t, []internal.PreviewedPath{
+		{Path: "local.test", Option: "Go", Pattern: previewed[0].Pattern, Tracked: false},
+		{Path: "testdata/app.test", Option: "Go", Pattern: previewed[1].Pattern, Tracked: true},
+	}, previewed)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestPreviewIgnoredShouldSkipPathsThatAreAlreadyIgnored(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := t.TempDir()
+
+	writeTemplates(t, repoDir, map[string]string{
+		".gitignore":                   "*.log\n",
+		"debug.log":                    "",
+		filepath.Join("logs", "a.log"): "",
+	})
+
+	previewed, err := internal.PreviewIgnored(repoDir, []internal.Section{
+		newSection("Logs", "*.log\nlogs/\n"),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, previewed, 1)
+	require.Equal(t, "logs/", previewed[0].Path)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestPreviewIgnoredShouldNotListDirectoriesWithOnlyTrackedFiles(t *testing.T) {
+	setGitEnvironment(t)
+	repoDir := newTemplateRepository(t, map[string]string{
+		filepath.Join("build", "keep.sh"): "#!/bin/sh\n",
+		filepath.Join("dist", "keep.sh"):  "#!/bin/sh\n",
+	})
+
+	writeTemplates(t, repoDir, map[string]string{filepath.Join("dist", "app.js"): ""})
+
+	previewed, err := internal.PreviewIgnored(repoDir, []internal.Section{
+		newSection("Build", "build/\ndist/\n"),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, previewed, 3)
+	require.Equal(t, "dist/", previewed[0].Path)
+	require.False(t, previewed[0].Tracked)
+	require.Equal(t, "build/keep.sh", previewed[1].Path)
+	require.True(t, previewed[1].Tracked)
+	require.Equal(t, "dist/keep.sh", previewed[2].Path)
+	require.True(t, previewed[2].Tracked)
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotARepository is returned when a directory isn't inside of a
+// git repository.
+var ErrNotARepository = errors.New("not inside a git repository")
+
+// FindRepositoryRoot walks up from the given directory until it finds
+// the root of the git repository containing it, i.e. the directory
+// with a .git directory or file in it.
+func FindRepositoryRoot(directory string) (string, error) {
+	currentDir, err := filepath.Abs(directory)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve %s: %w", directory, err)
+	}
+
+	for {
+		_, err := os.Stat(filepath.Join(currentDir, ".git"))
+		if err == nil {
+			return currentDir, nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("unable to check %s for a git repository: %w", currentDir, err)
+		}
+
+		parentDir := filepath.Dir(currentDir)
+		if parentDir == currentDir {
+			return "", ErrNotARepository
+		}
+
+		currentDir = parentDir
+	}
+}
+
+// FindGitDir returns the git directory of the repository containing
+// the given directory. GIT_DIR is used if it is set. For a linked
+// worktree this is the worktree's own directory inside of the main
+// repository's git directory.
+func FindGitDir(directory string) (string, error) {
+	if gitDir := os.Getenv("GIT_DIR"); gitDir != "" {
+		return filepath.Abs(gitDir)
+	}
+
+	repoRoot, err := FindRepositoryRoot(directory)
+	if err != nil {
+		return "", err
+	}
+
+	gitPath := filepath.Join(repoRoot, ".git")
+
+	info, err := os.Stat(gitPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", gitPath, err)
+	}
+
+	if info.IsDir() {
+		return gitPath, nil
+	}
+
+	// Worktrees and submodules have a .git file pointing at their
+	// git directory instead.
+	contents, err := os.ReadFile(gitPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", gitPath, err)
+	}
+
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(contents)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("unable to find the git directory in %s", gitPath)
+	}
+
+	return resolvePath(repoRoot, strings.TrimSpace(gitDir)), nil
+}
+
+// FindCommonGitDir returns the git directory that is shared by all of
+// the worktrees of the repository containing the given directory.
+// GIT_COMMON_DIR is used if it is set.
+func FindCommonGitDir(directory string) (string, error) {
+	if commonDir := os.Getenv("GIT_COMMON_DIR"); commonDir != "" {
+		return filepath.Abs(commonDir)
+	}
+
+	gitDir, err := FindGitDir(directory)
+	if err != nil {
+		return "", err
+	}
+
+	// Linked worktrees record where the shared git directory is.
+	contents, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if errors.Is(err, os.ErrNotExist) {
+		return gitDir, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("unable to read the common git directory of %s: %w", gitDir, err)
+	}
+
+	return resolvePath(gitDir, strings.TrimSpace(string(contents))), nil
+}
+
+// ExcludeFilePath returns the path of the info/exclude file for the
+// repository containing the given directory. Rules in it only apply
+// to the local clone and are never committed.
+func ExcludeFilePath(directory string) (string, error) {
+	commonDir, err := FindCommonGitDir(directory)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(commonDir, "info", "exclude"), nil
+}
+
+// resolvePath resolves a path that may be relative to the given
+// directory.
+func resolvePath(directory string, filePath string) string {
+	if filepath.IsAbs(filePath) {
+		return filepath.Clean(filePath)
+	}
+
+	return filepath.Join(directory, filePath)
+}
@@ -0,0 +1,120 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestFindRepositoryRootShouldFindTheRootFromASubdirectory(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	subDir := filepath.Join(repoDir, "a", "b")
+
+	err := os.MkdirAll(filepath.Join(repoDir, ".git"), 0o750)
+	require.NoError(t, err)
+
+	err = os.MkdirAll(subDir, 0o750)
+	require.NoError(t, err)
+
+	repoRoot, err := internal.FindRepositoryRoot(subDir)
+
+	require.NoError(t, err)
+	require.Equal(t, repoDir, repoRoot)
+}
+
+func TestFindRepositoryRootShouldSupportGitFiles(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	writeTemplates(t, repoDir, map[string]string{
+		".git": "gitdir: /somewhere/else/.git/worktrees/repo\n",
+	})
+
+	repoRoot, err := internal.FindRepositoryRoot(repoDir)
+
+	require.NoError(t, err)
+	require.Equal(t, repoDir, repoRoot)
+}
+
+func TestFindRepositoryRootShouldReturnAnErrorOutsideOfARepository(t *testing.T) {
+	t.Parallel()
+
+	_, err := internal.FindRepositoryRoot(t.TempDir())
+
+	require.ErrorIs(t, err, internal.ErrNotARepository)
+}
+
+func TestExcludeFilePathShouldBeInsideTheGitDirectory(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	subDir := filepath.Join(repoDir, "src")
+
+	err := os.MkdirAll(filepath.Join(repoDir, ".git"), 0o750)
+	require.NoError(t, err)
+
+	err = os.MkdirAll(subDir, 0o750)
+	require.NoError(t, err)
+
+	excludePath, err := internal.ExcludeFilePath(subDir)
+
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(repoDir, ".git", "info", "exclude"), excludePath)
+}
+
+func TestExcludeFilePathShouldUseTheMainRepositoryForWorktrees(t *testing.T) {
+	t.Parallel()
+
+	mainDir := t.TempDir()
+	worktreeDir := t.TempDir()
+
+	writeTemplates(t, mainDir, map[string]string{
+		".git/worktrees/feature/commondir": "../..\n",
+	})
+	writeTemplates(t, worktreeDir, map[string]string{
+		".git": "gitdir: " + filepath.Join(mainDir, ".git", "worktrees", "feature") + "\n",
+	})
+
+	gitDir, err := internal.FindGitDir(worktreeDir)
+
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(mainDir, ".git", "worktrees", "feature"), gitDir)
+
+	excludePath, err := internal.ExcludeFilePath(worktreeDir)
+
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(mainDir, ".git", "info", "exclude"), excludePath)
+}
+
+func TestFindGitDirShouldResolveRelativeGitFiles(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	writeTemplates(t, repoDir, map[string]string{
+		"modules/lib/HEAD": "ref: refs/heads/main\n",
+		"lib/.git":         "gitdir: ../modules/lib\n",
+	})
+
+	gitDir, err := internal.FindGitDir(filepath.Join(repoDir, "lib"))
+
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(repoDir, "modules", "lib"), gitDir)
+}
+
+//nolint:paralleltest // Modifies the environment
+func TestExcludeFilePathShouldRespectGitDir(t *testing.T) {
+	gitDir := t.TempDir()
+	t.Setenv("GIT_DIR", gitDir)
+	t.Setenv("GIT_COMMON_DIR", "")
+
+	excludePath, err := internal.ExcludeFilePath(t.TempDir())
+
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(gitDir, "info", "exclude"), excludePath)
+}
@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const maxSuggestions = 5
+
+// InvalidOptionError is returned when no adapter provides an option.
+type InvalidOptionError struct {
+	Option string
+
+	// Suggestions are valid options that are close to the one that
+	// was given, best match first.
+	Suggestions []string
+}
+
+func (err *InvalidOptionError) Error() string {
+	if len(err.Suggestions) == 0 {
+		return fmt.Sprintf("invalid option \"%s\"", err.Option)
+	}
+
+	return fmt.Sprintf(
+		"invalid option \"%s\", did you mean %s?",
+		err.Option,
+		strings.Join(err.Suggestions, ", "),
+	)
+}
+
+// SuggestOptions returns the options that are close to the given
+// option, best match first. Options are considered close if they are
+// a small number of edits away or if one contains the other.
+func SuggestOptions(options []string, option string) []string {
+	type suggestion struct {
+		option string
+		score  int
+	}
+
+	target := strings.ToLower(option)
+	maxDistance := max(1, len(target)/3)
+	suggestions := []suggestion{}
+	seen := map[string]bool{}
+
+	for _, candidate := range options {
+		folded := strings.ToLower(candidate)
+		if seen[folded] {
+			continue
+		}
+
+		seen[folded] = true
+
+		// Lower scores are better matches. Edit distance is weighted
+		// the heaviest since typos are the most common mistake.
+		score := -1
+
+		switch distance := editDistance(target, folded); {
+		case distance <= maxDistance:
+			score = distance
+		case len(target) >= 2 && strings.HasPrefix(folded, target):
+			score = maxDistance + 1
+		case len(target) >= 3 && strings.Contains(folded, target):
+			score = maxDistance + 2
+		}
+
+		if score >= 0 {
+			suggestions = append(suggestions, suggestion{option: candidate, score: score})
+		}
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b suggestion) int {
+		if a.score != b.score {
+			return a.score - b.score
+		}
+
+		return strings.Compare(strings.ToLower(a.option), strings.ToLower(b.option))
+	})
+
+	results := []string{}
+	for _, suggestion := range suggestions[:min(len(suggestions), maxSuggestions)] {
+		results = append(results, suggestion.option)
+	}
+
+	return results
+}
+
+// editDistance calculates the optimal string alignment distance
+// between two strings, i.e. the number of insertions, deletions,
+// substitutions and transpositions of adjacent characters needed to
+// turn one into the other.
+func editDistance(a string, b string) int {
+	source := []rune(a)
+	target := []rune(b)
+
+	distances := make([][]int, len(source)+1)
+	for row := range distances {
+		distances[row] = make([]int, len(target)+1)
+		distances[row][0] = row
+	}
+
+	for column := range distances[0] {
+		distances[0][column] = column
+	}
+
+	for row := 1; row <= len(source); row++ {
+		for column := 1; column <= len(target); column++ {
+			cost := 1
+			if source[row-1] == target[column-1] {
+				cost = 0
+			}
+
+			distances[row][column] = min(
+				distances[row-1][column]+1,
+				distances[row][column-1]+1,
+				distances[row-1][column-1]+cost,
+			)
+
+			if row > 1 && column > 1 &&
+				source[row-1] == target[column-2] &&
+				source[row-2] == target[column-1] {
+				distances[row][column] = min(distances[row][column], distances[row-2][column-2]+1)
+			}
+		}
+	}
+
+	return distances[len(source)][len(target)]
+}
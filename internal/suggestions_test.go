@@ -0,0 +1,43 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/durandj/git-ignore/internal"
+)
+
+func TestSuggestOptionsShouldSuggestCloseMatches(t *testing.T) {
+	t.Parallel()
+
+	options := []string{"C", "C++", "Go", "Node", "Python", "PyCharm", "Ruby", "VisualStudioCode"}
+
+	testCases := []struct {
+		option              string
+		expectedSuggestions []string
+	}{
+		{option: "Pyhton", expectedSuggestions: []string{"Python"}},
+		{option: "pythn", expectedSuggestions: []string{"Python"}},
+		{option: "Py", expectedSuggestions: []string{"PyCharm", "Python"}},
+		{option: "Studio", expectedSuggestions: []string{"VisualStudioCode"}},
+		{option: "Rubyy", expectedSuggestions: []string{"Ruby"}},
+		{option: "Haskell", expectedSuggestions: []string{}},
+	}
+
+	for _, testCase := range testCases {
+		suggestions := internal.SuggestOptions(options, testCase.option)
+
+		require.Equal(t, testCase.expectedSuggestions, suggestions, testCase.option)
+	}
+}
+
+func TestSuggestOptionsShouldLimitTheNumberOfSuggestions(t *testing.T) {
+	t.Parallel()
+
+	options := []string{"Go1", "Go2", "Go3", "Go4", "Go5", "Go6", "Go7"}
+
+	suggestions := internal.SuggestOptions(options, "Go")
+
+	require.Len(t, suggestions, 5)
+}
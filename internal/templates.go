@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const templateExtension = ".gitignore"
+
+// AmbiguousOptionError is returned when an option matches templates
+// at more than one path and so can't be resolved on its own.
+type AmbiguousOptionError struct {
+	Option string
+
+	// Candidates are the paths of all the templates the option
+	// matched.
+	Candidates []string
+}
+
+func (err *AmbiguousOptionError) Error() string {
+	return fmt.Sprintf(
+		"option \"%s\" is ambiguous, use one of: %s",
+		err.Option,
+		strings.Join(err.Candidates, ", "),
+	)
+}
+
+// templateReader reads the contents of the template at the given
+// path.
+type templateReader func(templatePath string) ([]byte, error)
+
+// directoryTemplateReader reads templates from the given directory.
+func directoryTemplateReader(directory string) templateReader {
+	return func(templatePath string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(directory, filepath.FromSlash(templatePath)+templateExtension))
+	}
+}
+
+// renderTemplates concatenates the templates for the given options
+// into a single gitignore file. Options can either be the name of a
+// template or its path, e.g. "Hugo" or "community/Golang/Hugo".
+func renderTemplates(readTemplate templateReader, index *templateIndex, options []string) (string, error) {
+	templatePaths := make([]string, 0, len(options))
+
+	for _, option := range options {
+		templatePath, err := index.resolve(option)
+		if err != nil {
+			return "", err
+		}
+
+		templatePaths = append(templatePaths, templatePath)
+	}
+
+	var builder strings.Builder
+	for position, templatePath := range templatePaths {
+		contents, err := readTemplate(templatePath)
+		if err != nil {
+			return "", fmt.Errorf("unable to read gitignore data for %s: %w", options[position], err)
+		}
+
+		if !bytes.HasPrefix(contents, []byte("###")) {
+			builder.WriteString(fmt.Sprintf("### %s ###\n", path.Base(templatePath)))
+		}
+
+		builder.Write(contents)
+		builder.WriteString("\n")
+	}
+
+	return builder.String(), nil
+}